@@ -1,35 +1,100 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/akshaybt001/DatingApp_UserService/config"
 	"github.com/akshaybt001/DatingApp_UserService/db"
+	"github.com/akshaybt001/DatingApp_UserService/healthcheck"
 	"github.com/akshaybt001/DatingApp_UserService/initializer"
+	"github.com/akshaybt001/DatingApp_UserService/internal/validation"
+	"github.com/akshaybt001/DatingApp_UserService/logging"
+	"github.com/akshaybt001/DatingApp_UserService/metrics"
 	"github.com/akshaybt001/DatingApp_proto_files/pb"
-	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
 func main() {
-	if err := godotenv.Load("../.env"); err != nil {
-		log.Fatalf(err.Error())
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	logger := logging.New(os.Stdout, cfg.Logging)
+	slog.SetDefault(logger)
+	if cfg.HealthCheck {
+		if err := healthcheck.Probe("localhost:"+cfg.Port, 3*time.Second); err != nil {
+			log.Fatalf("health check failed: %v", err)
+		}
+		return
 	}
-	addr := os.Getenv("DB_KEY")
-	DB, err := db.InitDB(addr)
+	DB, err := db.InitDB(cfg.DBKey)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	services := initializer.Initializer(DB)
-	server := grpc.NewServer()
-	pb.RegisterUserServiceServer(server, services)
-	listener, err := net.Listen("tcp", ":8081")
+	app, err := initializer.Initializer(DB, cfg)
+	if err != nil {
+		log.Fatalf("failed to initialise services: %v", err)
+	}
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor, logging.UnaryServerInterceptor(logger), validation.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor, logging.StreamServerInterceptor(logger), validation.StreamServerInterceptor),
+	)
+	pb.RegisterUserServiceServer(server, app.Service)
+	app.Health.Register(server)
+	listener, err := net.Listen("tcp", ":"+cfg.Port)
 	if err != nil {
-		log.Fatalf("failed to listen on port 8081 %v", err)
+		log.Fatalf("failed to listen on port %s %v", cfg.Port, err)
 	}
-	log.Printf("user service listening on port 8081")
-	if err = server.Serve(listener); err != nil {
-		log.Fatalf("failed to listen on port 8081 %v", err)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("user service listening on port %s", cfg.Port)
+		serveErr <- server.Serve(listener)
+	}()
+
+	exitCode := 0
+	select {
+	case err := <-serveErr:
+		log.Printf("failed to serve on port %s %v", cfg.Port, err)
+		exitCode = 1
+	case <-ctx.Done():
+		log.Printf("shutting down, draining requests for up to %s", cfg.ShutdownTimeout)
+		app.Health.Shutdown()
+		gracefulStop(server, cfg.ShutdownTimeout)
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		log.Printf("error during shutdown: %v", err)
+	}
+	cancel()
+	log.Printf("user service stopped")
+	os.Exit(exitCode)
+}
+
+// gracefulStop lets in-flight RPCs finish and falls back to a hard stop
+// once timeout has passed.
+func gracefulStop(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Printf("drain timeout reached, closing remaining connections")
+		server.Stop()
 	}
 }
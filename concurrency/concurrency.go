@@ -1,10 +1,13 @@
 package concurrency
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"sync"
 
 	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_UserService/metrics"
 	"github.com/robfig/cron"
 	"gorm.io/gorm"
 )
@@ -12,6 +15,8 @@ import (
 type CronJob struct {
 	service *service.UserService
 	DB      *gorm.DB
+	cron    *cron.Cron
+	running sync.RWMutex
 }
 
 func NewCronJob(service *service.UserService, db *gorm.DB) *CronJob {
@@ -21,22 +26,95 @@ func NewCronJob(service *service.UserService, db *gorm.DB) *CronJob {
 	}
 }
 func (c *CronJob) Start() {
-	cron := cron.New()
-	err := cron.AddFunc("23 59 * * *", func() {
+	c.cron = cron.New()
+	err := c.cron.AddFunc("23 59 * * *", c.track(func() {
 		c.UpdateLikeCount()
-	})
+	}))
 	if err != nil {
-		log.Print("error scheduling cron job ", err)
+		slog.Error("error scheduling cron job", "error", err)
+	}
+	// Every day at 03:00; the first field is the second.
+	err = c.cron.AddFunc("0 0 3 * * *", c.track(func() {
+		if err := c.PurgeDeletedAccounts(); err != nil {
+			slog.Error("purging deleted accounts failed", "error", err)
+		}
+	}))
+	if err != nil {
+		slog.Error("error scheduling cron job", "error", err)
+	}
+	// Every 15 minutes, so lapsed subscribers lose the subscribed flag and
+	// extra likes soon after their subscription ends.
+	err = c.cron.AddFunc("0 */15 * * * *", c.track(func() {
+		if err := c.ExpireSubscriptions(); err != nil {
+			slog.Error("expiring subscriptions failed", "error", err)
+		}
+	}))
+	if err != nil {
+		slog.Error("error scheduling cron job", "error", err)
+	}
+	c.cron.Start()
+}
+
+// track wraps a job so Stop can wait for runs that are already in progress.
+// Each run holds a read lock; once Stop asks for the write lock, runs that
+// have not started yet stay blocked instead of racing the shutdown.
+func (c *CronJob) track(job func()) func() {
+	return func() {
+		c.running.RLock()
+		defer c.running.RUnlock()
+		job()
+	}
+}
+
+// Stop prevents new runs from being scheduled and waits for in-flight runs
+// to finish, or for ctx to be done, whichever comes first.
+func (c *CronJob) Stop(ctx context.Context) error {
+	if c.cron != nil {
+		c.cron.Stop()
+	}
+	done := make(chan struct{})
+	go func() {
+		c.running.Lock()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for cron jobs to finish: %w", ctx.Err())
 	}
-	cron.Start()
 }
 
+// UpdateLikeCount resets the daily likes of every user to the quota of
+// their plan.
 func (c *CronJob) UpdateLikeCount() error {
-	err := c.DB.Exec("UPDATE users SET like_count = 3").Error
+	reset, err := c.service.ResetLikeQuotas(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to update like_count: %w", err)
 	}
+	metrics.LikeQuotaResets.Add(float64(reset))
 
-	fmt.Println("Like count for all users set to 3 successfully")
+	slog.Info("like count reset for all users", "users", reset)
+	return nil
+}
+
+// ExpireSubscriptions moves users whose subscription ran out to the free
+// plan.
+func (c *CronJob) ExpireSubscriptions() error {
+	expired, err := c.service.ExpireSubscriptions(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to expire subscriptions: %w", err)
+	}
+	slog.Info("lapsed subscriptions expired", "subscriptions", expired)
+	return nil
+}
+
+// PurgeDeletedAccounts removes accounts whose deletion grace period is over.
+func (c *CronJob) PurgeDeletedAccounts() error {
+	purged, err := c.service.PurgeDeletedAccounts(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to purge deleted accounts: %w", err)
+	}
+	slog.Info("deleted accounts purged", "users", purged)
 	return nil
 }
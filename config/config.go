@@ -0,0 +1,272 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	Port                string
+	DBKey               string
+	ShutdownTimeout     time.Duration
+	HealthCheckInterval time.Duration
+	// HealthCheck runs the binary as a probe against an already running
+	// instance instead of starting the service.
+	HealthCheck bool
+	// MetricsAddr is where the Prometheus endpoint listens; empty disables it.
+	MetricsAddr string
+	Logging     Logging
+	Tracing     Tracing
+	Redis       Redis
+	Minio       Minio
+
+	// MaxInterestsPerUser caps how many interests a user can have.
+	MaxInterestsPerUser int
+	// AccountPurgeGrace is how long a deleted account is kept before its
+	// data is purged.
+	AccountPurgeGrace time.Duration
+	// SeenRecycleAfter is how long a profile the user passed on stays out
+	// of their recommendations.
+	SeenRecycleAfter time.Duration
+	// LikedRecycleAfter is the same for profiles the user liked; zero keeps
+	// them out for good.
+	LikedRecycleAfter time.Duration
+	// ResetSeenOnPreferenceChange lets the profiles a user passed on be
+	// recommended again as soon as they change their preference.
+	ResetSeenOnPreferenceChange bool
+}
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+type Logging struct {
+	Level  slog.Level
+	Format string
+}
+
+type Tracing struct {
+	// Exporter is one of none, otlp or stdout.
+	Exporter string
+	// Endpoint is the OTLP collector address used by the otlp exporter.
+	Endpoint string
+	Insecure bool
+	// File receives spans from the stdout exporter; empty means stdout.
+	File string
+}
+
+type Redis struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+type Minio struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	UseSSL    bool
+}
+
+// defaultEnvFile is where the service has always looked for its env file,
+// relative to the cmd directory it is started from.
+const defaultEnvFile = "../.env"
+
+// MissingError lists every required setting that was not provided, so a
+// misconfigured deployment is reported in one go instead of one restart per
+// missing value.
+type MissingError struct {
+	Keys []string
+}
+
+func (e *MissingError) Error() string {
+	return "missing required configuration: " + strings.Join(e.Keys, ", ")
+}
+
+func defaults() map[string]string {
+	return map[string]string{
+		"GRPC_PORT":                       "8081",
+		"SHUTDOWN_TIMEOUT":                "15s",
+		"HEALTH_INTERVAL":                 "10s",
+		"LOG_LEVEL":                       "info",
+		"LOG_FORMAT":                      LogFormatText,
+		"TRACING_EXPORTER":                "none",
+		"OTLP_ENDPOINT":                   "localhost:4317",
+		"OTLP_INSECURE":                   "true",
+		"REDIS_ADDR":                      "redis-service:6379",
+		"REDIS_DB":                        "0",
+		"MAX_INTERESTS":                   "10",
+		"ACCOUNT_PURGE_GRACE":             "720h",
+		"SEEN_RECYCLE_AFTER":              "720h",
+		"LIKED_RECYCLE_AFTER":             "0s",
+		"RESET_SEEN_ON_PREFERENCE_CHANGE": "false",
+	}
+}
+
+var keys = []string{"GRPC_PORT", "SHUTDOWN_TIMEOUT", "HEALTH_INTERVAL", "METRICS_ADDR", "MAX_INTERESTS", "ACCOUNT_PURGE_GRACE", "SEEN_RECYCLE_AFTER", "LIKED_RECYCLE_AFTER", "RESET_SEEN_ON_PREFERENCE_CHANGE", "LOG_LEVEL", "LOG_FORMAT", "TRACING_EXPORTER", "OTLP_ENDPOINT", "OTLP_INSECURE", "TRACING_FILE", "DB_KEY", "REDIS_ADDR", "REDIS_PASSWORD", "REDIS_DB", "MINIO_ENDPOINT", "MINIO_ACCESSKEY", "MINIO_SECRETKEY", "BUCKET_NAME", "MINIO_USE_SSL"}
+
+var required = []string{"DB_KEY", "MINIO_ENDPOINT", "MINIO_ACCESSKEY", "MINIO_SECRETKEY", "BUCKET_NAME"}
+
+// Load builds the configuration from, in increasing order of precedence,
+// built-in defaults, the env file, the process environment and the command
+// line flags in args. The env file is optional unless it was named
+// explicitly with -config. With -healthcheck only the port is needed, so
+// required settings are not enforced.
+func Load(args []string) (Config, error) {
+	fs := flag.NewFlagSet("user-service", flag.ContinueOnError)
+	envFile := fs.String("config", defaultEnvFile, "path to an env file with configuration values")
+	port := fs.String("port", "", "port the gRPC server listens on")
+	dbKey := fs.String("db", "", "postgres connection string")
+	redisAddr := fs.String("redis-addr", "", "redis address")
+	healthCheck := fs.Bool("healthcheck", false, "probe the health of a running instance and exit")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	values := defaults()
+	file, err := godotenv.Read(*envFile)
+	if err != nil {
+		if explicit["config"] || !errors.Is(err, os.ErrNotExist) {
+			return Config{}, fmt.Errorf("reading config file %s: %w", *envFile, err)
+		}
+	}
+	for k, v := range file {
+		values[k] = v
+	}
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok {
+			values[k] = v
+		}
+	}
+	if explicit["port"] {
+		values["GRPC_PORT"] = *port
+	}
+	if explicit["db"] {
+		values["DB_KEY"] = *dbKey
+	}
+	if explicit["redis-addr"] {
+		values["REDIS_ADDR"] = *redisAddr
+	}
+	return parse(values, *healthCheck)
+}
+
+func parse(values map[string]string, healthCheck bool) (Config, error) {
+	var missing []string
+	for _, k := range required {
+		if !healthCheck && strings.TrimSpace(values[k]) == "" {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		return Config{}, &MissingError{Keys: missing}
+	}
+	redisDB, err := strconv.Atoi(values["REDIS_DB"])
+	if err != nil {
+		return Config{}, fmt.Errorf("REDIS_DB must be a number: %w", err)
+	}
+	useSSL := false
+	if v := values["MINIO_USE_SSL"]; v != "" {
+		useSSL, err = strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("MINIO_USE_SSL must be a boolean: %w", err)
+		}
+	}
+	shutdownTimeout, err := time.ParseDuration(values["SHUTDOWN_TIMEOUT"])
+	if err != nil {
+		return Config{}, fmt.Errorf("SHUTDOWN_TIMEOUT must be a duration: %w", err)
+	}
+	var logLevel slog.Level
+	if err := logLevel.UnmarshalText([]byte(values["LOG_LEVEL"])); err != nil {
+		return Config{}, fmt.Errorf("LOG_LEVEL must be one of debug, info, warn or error: %w", err)
+	}
+	if f := values["LOG_FORMAT"]; f != LogFormatText && f != LogFormatJSON {
+		return Config{}, fmt.Errorf("LOG_FORMAT must be text or json")
+	}
+	otlpInsecure, err := strconv.ParseBool(values["OTLP_INSECURE"])
+	if err != nil {
+		return Config{}, fmt.Errorf("OTLP_INSECURE must be a boolean: %w", err)
+	}
+	switch values["TRACING_EXPORTER"] {
+	case "none", "otlp", "stdout":
+	default:
+		return Config{}, fmt.Errorf("TRACING_EXPORTER must be one of none, otlp or stdout")
+	}
+	healthInterval, err := time.ParseDuration(values["HEALTH_INTERVAL"])
+	if err != nil || healthInterval <= 0 {
+		return Config{}, fmt.Errorf("HEALTH_INTERVAL must be a positive duration")
+	}
+	maxInterests, err := strconv.Atoi(values["MAX_INTERESTS"])
+	if err != nil || maxInterests <= 0 {
+		return Config{}, fmt.Errorf("MAX_INTERESTS must be a positive number")
+	}
+	purgeGrace, err := time.ParseDuration(values["ACCOUNT_PURGE_GRACE"])
+	if err != nil || purgeGrace <= 0 {
+		return Config{}, fmt.Errorf("ACCOUNT_PURGE_GRACE must be a positive duration")
+	}
+	seenRecycle, err := time.ParseDuration(values["SEEN_RECYCLE_AFTER"])
+	if err != nil || seenRecycle <= 0 {
+		return Config{}, fmt.Errorf("SEEN_RECYCLE_AFTER must be a positive duration")
+	}
+	likedRecycle, err := time.ParseDuration(values["LIKED_RECYCLE_AFTER"])
+	if err != nil || likedRecycle < 0 {
+		return Config{}, fmt.Errorf("LIKED_RECYCLE_AFTER must be a duration, or 0s to never recycle")
+	}
+	resetSeen, err := strconv.ParseBool(values["RESET_SEEN_ON_PREFERENCE_CHANGE"])
+	if err != nil {
+		return Config{}, fmt.Errorf("RESET_SEEN_ON_PREFERENCE_CHANGE must be a boolean: %w", err)
+	}
+	port := strings.TrimPrefix(values["GRPC_PORT"], ":")
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return Config{}, fmt.Errorf("GRPC_PORT must be a valid port: %w", err)
+	}
+	return Config{
+		Port:                port,
+		DBKey:               values["DB_KEY"],
+		ShutdownTimeout:     shutdownTimeout,
+		HealthCheckInterval: healthInterval,
+		HealthCheck:         healthCheck,
+		MetricsAddr:         values["METRICS_ADDR"],
+		MaxInterestsPerUser: maxInterests,
+		AccountPurgeGrace:   purgeGrace,
+		SeenRecycleAfter:    seenRecycle,
+		LikedRecycleAfter:   likedRecycle,
+
+		ResetSeenOnPreferenceChange: resetSeen,
+		Logging: Logging{
+			Level:  logLevel,
+			Format: values["LOG_FORMAT"],
+		},
+		Tracing: Tracing{
+			Exporter: values["TRACING_EXPORTER"],
+			Endpoint: values["OTLP_ENDPOINT"],
+			Insecure: otlpInsecure,
+			File:     values["TRACING_FILE"],
+		},
+		Redis: Redis{
+			Addr:     values["REDIS_ADDR"],
+			Password: values["REDIS_PASSWORD"],
+			DB:       redisDB,
+		},
+		Minio: Minio{
+			Endpoint:  values["MINIO_ENDPOINT"],
+			AccessKey: values["MINIO_ACCESSKEY"],
+			SecretKey: values["MINIO_SECRETKEY"],
+			Bucket:    values["BUCKET_NAME"],
+			UseSSL:    useSSL,
+		},
+	}, nil
+}
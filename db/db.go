@@ -1,29 +1,259 @@
 package db
 
 import (
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
 	"github.com/akshaybt001/DatingApp_UserService/entities"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
 func InitDB(connectTo string) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(connectTo), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		// Parameterized queries keep emails, phone numbers and password
+		// hashes out of the SQL log.
+		Logger: logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+			SlowThreshold:        200 * time.Millisecond,
+			LogLevel:             logger.Info,
+			ParameterizedQueries: true,
+			Colorful:             true,
+		}),
+		// Unique violations are reported as gorm.ErrDuplicatedKey.
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 	db.AutoMigrate(&entities.User{})
+	db.AutoMigrate(&entities.PendingAccountChange{})
+	db.AutoMigrate(&entities.AccountAudit{})
+	db.AutoMigrate(&entities.UserLike{})
+	db.AutoMigrate(&entities.Plan{})
+	db.AutoMigrate(&entities.Subscription{})
+	db.AutoMigrate(&entities.SubscriptionEvent{})
 	db.AutoMigrate(&entities.Admin{})
 	db.AutoMigrate(&entities.Gender{})
+	db.AutoMigrate(&entities.InterestCategory{})
 	db.AutoMigrate(&entities.Interests{})
 	db.AutoMigrate(&entities.UserInterests{})
+	db.AutoMigrate(&entities.Translation{})
 	db.AutoMigrate(&entities.Address{})
 	db.AutoMigrate(&entities.Preference{})
+	db.AutoMigrate(&entities.PreferenceGenders{})
 	db.AutoMigrate(&entities.UserGenders{})
 	db.AutoMigrate(&entities.Profile{})
+	db.AutoMigrate(&entities.ProfilePrompt{})
 	db.AutoMigrate(&entities.Images{})
+	if err := backfillDateOfBirth(db); err != nil {
+		return nil, err
+	}
+	if err := backfillPreferenceGenders(db); err != nil {
+		return nil, err
+	}
+	if err := seedPlans(db); err != nil {
+		return nil, err
+	}
+	if err := backfillSubscriptions(db); err != nil {
+		return nil, err
+	}
+	if err := normalizeAccounts(db); err != nil {
+		return nil, err
+	}
+	if err := reportDuplicateAccounts(db); err != nil {
+		return nil, err
+	}
+	if err := reportInvalidPhones(db); err != nil {
+		return nil, err
+	}
+	if err := syncIdSequences(db, serialTables); err != nil {
+		return nil, err
+	}
+	createUniqueIndexes(db, catalogIndexes, "merge duplicate entries and restart")
+	createUniqueIndexes(db, userInterestIndexes, "remove repeated interests of a profile and restart")
+	createUniqueIndexes(db, accountIndexes, "resolve accounts sharing an email or phone and restart")
+	if err := dropIndexes(db, replacedIndexes); err != nil {
+		return nil, err
+	}
+	createUniqueIndexes(db, subscriptionIndexes, "cancel all but one active subscription per user and restart")
 	return db, nil
 
 }
+
+// backfillDateOfBirth estimates a date of birth for profiles that only have
+// the age stored before dates of birth were kept. The estimate gives the
+// stored age today and is replaced once the user sets their date of birth.
+func backfillDateOfBirth(db *gorm.DB) error {
+	updateQuery := `UPDATE profiles SET date_of_birth = CURRENT_DATE - make_interval(years => age) WHERE date_of_birth IS NULL AND age > 0`
+	return db.Exec(updateQuery).Error
+}
+
+// backfillPreferenceGenders copies the single gender of preferences saved
+// before preferences held a set of genders.
+func backfillPreferenceGenders(db *gorm.DB) error {
+	insertQuery := `INSERT INTO preference_genders (preference_id, gender_id) SELECT p.id, p.gender_id FROM preferences p WHERE p.gender_id > 0 AND NOT EXISTS (SELECT 1 FROM preference_genders pg WHERE pg.preference_id = p.id)`
+	return db.Exec(insertQuery).Error
+}
+
+// defaultPlans is the plan catalog a new database starts with. Existing
+// plans are left as they are, so entitlements can be tuned in the table.
+var defaultPlans = []entities.Plan{
+	{Id: entities.PlanFree, Name: "Free", DailyLikes: 3},
+	{Id: entities.PlanPlus, Name: "Plus", DailyLikes: 50, DailySuperlikes: 1, DailyRewinds: 3},
+	{Id: entities.PlanGold, Name: "Gold", DailyLikes: 100, DailySuperlikes: 5, DailyRewinds: 10, SeeWhoLikedYou: true},
+}
+
+func seedPlans(db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaultPlans).Error
+}
+
+// backfillSubscriptions gives users flagged as subscribed before
+// subscriptions were kept a Plus subscription for one more period, so they
+// are not downgraded before they renew.
+func backfillSubscriptions(db *gorm.DB) error {
+	insertQuery := `INSERT INTO subscriptions (id, user_id, plan_id, status, started_at, expires_at, payment_ref) SELECT gen_random_uuid(), u.id, ?, ?, NOW(), NOW() + interval '30 days', '' FROM users u WHERE u.is_subscribed AND NOT EXISTS (SELECT 1 FROM subscriptions s WHERE s.user_id = u.id)`
+	return db.Exec(insertQuery, entities.PlanPlus, entities.SubscriptionActive).Error
+}
+
+// catalogIndexes keep interest, category and gender names unique regardless
+// of case.
+// Deleted entries are left out so a name can be added again. The admin
+// RPCs check names the same way, so duplicates are still refused while an
+// index cannot be built.
+var catalogIndexes = []string{
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_interests_name ON interests (lower(interest)) WHERE NOT is_deleted`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_interest_categories_name ON interest_categories (lower(name)) WHERE NOT is_deleted`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_genders_name ON genders (lower(name)) WHERE NOT is_deleted`,
+}
+
+// userInterestIndexes keep a profile from having the same interest twice.
+var userInterestIndexes = []string{
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_user_interests_profile_interest ON user_interests (profile_id, interest_id)`,
+}
+
+// serialTables have ids assigned by their sequence. Earlier versions
+// inserted MAX(id)+1 without advancing the sequence.
+var serialTables = []string{"interests", "genders", "interest_categories", "user_interests", "user_genders"}
+
+// syncIdSequences moves the id sequence of each table past its largest id,
+// so ids inserted by earlier versions are not handed out again.
+func syncIdSequences(db *gorm.DB, tables []string) error {
+	for _, table := range tables {
+		if err := db.Exec(`SELECT setval(pg_get_serial_sequence(?, 'id'), COALESCE(MAX(id), 0) + 1, false) FROM `+table, table).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountIndexes back the email and phone checks of signup and account
+// updates, so two accounts cannot end up with the same email or phone.
+// Emails are compared without case, as helper.NormalizeEmail stores them.
+var accountIndexes = []string{
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (lower(email))`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone ON users (phone)`,
+}
+
+// replacedIndexes are indexes of earlier versions that are now covered by
+// others; idx_users_email compared emails with case.
+var replacedIndexes = []string{"idx_users_email"}
+
+// subscriptionIndexes allow one active subscription per user.
+var subscriptionIndexes = []string{
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_subscriptions_active ON subscriptions (user_id) WHERE status = 'active'`,
+}
+
+// normalizedAccountColumns pairs the unique users columns with the SQL form
+// of helper.NormalizeEmail and helper.NormalizePhone; %[1]s is the alias of
+// the users table.
+var normalizedAccountColumns = []struct {
+	column     string
+	normalized string
+}{
+	{"email", `lower(trim(%[1]s.email))`},
+	{"phone", `regexp_replace(regexp_replace(%[1]s.phone, '[[:space:]().-]', '', 'g'), '^00', '+')`},
+}
+
+// normalizeAccounts rewrites emails and phones stored before they were
+// normalized. A value is left as it is when another account already has
+// the normalized form; reportDuplicateAccounts lists those accounts.
+func normalizeAccounts(db *gorm.DB) error {
+	for _, c := range normalizedAccountColumns {
+		updateQuery := fmt.Sprintf(`UPDATE users u SET %[1]s=%[2]s WHERE u.%[1]s <> %[2]s AND NOT EXISTS (SELECT 1 FROM users o WHERE o.id <> u.id AND %[3]s = %[2]s)`,
+			c.column, fmt.Sprintf(c.normalized, "u"), fmt.Sprintf(c.normalized, "o"))
+		if err := db.Exec(updateQuery).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportDuplicateAccounts warns about accounts that share an email or phone
+// once normalized. They keep the unique indexes from being created until
+// they are merged or changed.
+func reportDuplicateAccounts(db *gorm.DB) error {
+	for _, c := range normalizedAccountColumns {
+		var duplicates []struct {
+			Value   string
+			UserIds string
+		}
+		selectQuery := fmt.Sprintf(`SELECT %s AS value, string_agg(u.id::text, ',' ORDER BY u.created_at) AS user_ids FROM users u GROUP BY 1 HAVING COUNT(*) > 1`,
+			fmt.Sprintf(c.normalized, "u"))
+		if err := db.Raw(selectQuery).Scan(&duplicates).Error; err != nil {
+			return err
+		}
+		for _, duplicate := range duplicates {
+			slog.Warn("accounts share an "+c.column, c.column, duplicate.Value, "user_ids", duplicate.UserIds)
+		}
+	}
+	return nil
+}
+
+// phoneE164 is the SQL form of the E.164 check of the validation package.
+const phoneE164 = `^\+[1-9][0-9]{7,14}$`
+
+// reportInvalidPhones warns about accounts whose phone is not in E.164
+// form once normalized, such as numbers stored without a country code.
+// They cannot be normalized without knowing the country, and the unique
+// index does not see them as the same number as their E.164 form, so an
+// operator has to fix them.
+func reportInvalidPhones(db *gorm.DB) error {
+	var invalid []struct {
+		Id    string
+		Phone string
+	}
+	selectQuery := `SELECT id, phone FROM users WHERE phone <> '' AND phone !~ ? ORDER BY created_at`
+	if err := db.Raw(selectQuery, phoneE164).Scan(&invalid).Error; err != nil {
+		return err
+	}
+	for _, account := range invalid {
+		slog.Warn("account phone is not in E.164 form, add its country code", "user_id", account.Id, "phone", account.Phone)
+	}
+	return nil
+}
+
+// dropIndexes drops indexes that may be left from earlier versions.
+func dropIndexes(db *gorm.DB, indexes []string) error {
+	for _, index := range indexes {
+		if err := db.Exec(`DROP INDEX IF EXISTS ` + index).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createUniqueIndexes creates indexes. An index cannot be built while
+// duplicates exist; the service then starts without it and logs hint, and
+// the index is created on the next start once the duplicates are gone.
+func createUniqueIndexes(db *gorm.DB, indexes []string, hint string) {
+	for _, index := range indexes {
+		if err := db.Exec(index).Error; err != nil {
+			slog.Warn("could not create unique index, "+hint, "index", index, "error", err)
+		}
+	}
+}
@@ -17,11 +17,147 @@ type User struct {
 	LikeCount    int  `json:"like_count" gorm:"default:3"`
 	IsSubscribed bool `json:"is_subscribed" gorm:"default:false"`
 	CreatedAt    time.Time
+
+	// DeletedAt is set when the user asks to delete their account. The
+	// account is hidden and cannot log in from then on, and its data is
+	// purged once the grace period has passed.
+	DeletedAt *time.Time `gorm:"index"`
+	// DiscoveryMode is one of the Discovery modes below and decides who
+	// the user is recommended to.
+	DiscoveryMode int `gorm:"default:0"`
+}
+
+// Discovery modes of a User; the values match pb.DiscoveryMode. Paused users
+// are recommended to no one and incognito users only to users they liked.
+const (
+	DiscoveryVisible   = 0
+	DiscoveryPaused    = 1
+	DiscoveryIncognito = 2
+)
+
+// UserLike records that UserId liked LikedId. Likes are kept by the match
+// service; this copy lets candidate queries see who a user has liked.
+type UserLike struct {
+	UserId    uuid.UUID `json:"user_id" gorm:"primaryKey"`
+	User      User      `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+	LikedId   uuid.UUID `json:"liked_id" gorm:"primaryKey;index"`
+	Liked     User      `gorm:"foreignKey:LikedId;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Plan is a subscription tier and what it entitles its subscribers to.
+// Users without an active subscription get the entitlements of PlanFree.
+type Plan struct {
+	Id              string `json:"id" gorm:"primaryKey"`
+	Name            string `json:"name"`
+	DailyLikes      int    `json:"daily_likes"`
+	DailySuperlikes int    `json:"daily_superlikes"`
+	DailyRewinds    int    `json:"daily_rewinds"`
+	SeeWhoLikedYou  bool   `json:"see_who_liked_you"`
+}
+
+// UnlimitedLikes as the DailyLikes of a plan lifts the daily like limit.
+const UnlimitedLikes = -1
+
+const (
+	PlanFree = "free"
+	PlanPlus = "plus"
+	PlanGold = "gold"
+)
+
+// Subscription is a paid period on a Plan. A user has at most one active
+// subscription; it grants the plan until ExpiresAt, after which the expiry
+// job marks it expired. PaymentRef is the id the payment provider uses.
+type Subscription struct {
+	Id         uuid.UUID `json:"id" gorm:"primaryKey"`
+	UserId     uuid.UUID `json:"user_id" gorm:"index"`
+	User       User      `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+	PlanId     string    `json:"plan_id"`
+	Plan       Plan      `gorm:"foreignKey:PlanId"`
+	Status     string    `json:"status"`
+	StartedAt  time.Time `json:"started_at"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"index"`
+	PaymentRef string    `json:"payment_ref"`
+}
+
+// Statuses of a Subscription.
+const (
+	SubscriptionActive    = "active"
+	SubscriptionCancelled = "cancelled"
+	SubscriptionExpired   = "expired"
+)
+
+// SubscriptionEvent is an event received from the payment service. Events
+// are kept by their Id, so a replayed event is recognised, and only the
+// newest event of a user, by OccurredAt and then Id, decides their
+// subscription. Outcome says whether the event was applied or arrived
+// after a newer one.
+type SubscriptionEvent struct {
+	Id         string     `json:"id" gorm:"primaryKey"`
+	UserId     uuid.UUID  `json:"user_id" gorm:"index"`
+	User       User       `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+	Type       string     `json:"type"`
+	PlanId     string     `json:"plan_id"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	PaymentRef string     `json:"payment_ref"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	ReceivedAt time.Time  `json:"received_at"`
+	Outcome    string     `json:"outcome"`
+}
+
+// Types of a SubscriptionEvent.
+const (
+	SubscriptionEventActivated = "activated"
+	SubscriptionEventRenewed   = "renewed"
+	SubscriptionEventCancelled = "cancelled"
+	SubscriptionEventRefunded  = "refunded"
+)
+
+// Outcomes of a SubscriptionEvent. Duplicates are not stored again.
+const (
+	SubscriptionEventApplied   = "applied"
+	SubscriptionEventStale     = "stale"
+	SubscriptionEventDuplicate = "duplicate"
+)
+
+// PendingAccountChange is a new email or phone that is not used until it
+// has been verified. A user has at most one pending change per field.
+type PendingAccountChange struct {
+	UserId    uuid.UUID `json:"user_id" gorm:"primaryKey"`
+	User      User      `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+	Field     string    `json:"field" gorm:"primaryKey"`
+	Value     string    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// CodeHash is the helper.HashCode digest of the code that confirms the
+	// change; Attempts counts the wrong codes given so far.
+	CodeHash string `json:"-"`
+	Attempts int    `json:"attempts"`
+}
+
+// AccountAudit records a change to an account. OldValue and NewValue are
+// redacted so the log does not hold full emails or phone numbers.
+type AccountAudit struct {
+	Id        uuid.UUID `json:"id" gorm:"primaryKey"`
+	UserId    uuid.UUID `json:"user_id" gorm:"index"`
+	User      User      `gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+	Field     string    `json:"field"`
+	Event     string    `json:"event"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
+// Events of an AccountAudit.
+const (
+	AccountChangeApplied   = "applied"
+	AccountChangeRequested = "requested"
+	AccountChangeConfirmed = "confirmed"
+)
+
 type Gender struct {
-	Id   int    `json:"id" gorm:"primaryKey"`
-	Name string `json:"name" `
+	Id        int    `json:"id" gorm:"primaryKey"`
+	Name      string `json:"name" `
+	IsDeleted bool   `json:"is_deleted" gorm:"default:false"`
 }
 
 type UserInterests struct {
@@ -41,10 +177,38 @@ type UserGenders struct {
 }
 
 type Interests struct {
-	Id       int    `json:"id" gorm:"primaryKey"`
-	Interest string `json:"interest"`
+	Id         int               `json:"id" gorm:"primaryKey"`
+	Interest   string            `json:"interest"`
+	IsDeleted  bool              `json:"is_deleted" gorm:"default:false"`
+	CategoryId *int              `json:"category_id"`
+	Category   *InterestCategory `json:"category" gorm:"foreignKey:CategoryId;constraint:OnDelete:SET NULL"`
 }
 
+// InterestCategory groups interests in the catalog. Categories are listed by
+// Position and then by name.
+type InterestCategory struct {
+	Id        int    `json:"id" gorm:"primaryKey"`
+	Name      string `json:"name"`
+	Position  int    `json:"position"`
+	IsDeleted bool   `json:"is_deleted" gorm:"default:false"`
+}
+
+// Translation is the name of a catalog entry in a locale other than the
+// default. Entity is one of the Translation kinds below and EntityId the id
+// of the entry in its table.
+type Translation struct {
+	Entity   string `json:"entity" gorm:"primaryKey"`
+	EntityId int    `json:"entity_id" gorm:"primaryKey;autoIncrement:false"`
+	Locale   string `json:"locale" gorm:"primaryKey"`
+	Name     string `json:"name"`
+}
+
+const (
+	TranslationInterest         = "interest"
+	TranslationInterestCategory = "interest_category"
+	TranslationGender           = "gender"
+)
+
 type Admin struct {
 	ID       uuid.UUID
 	Name     string
@@ -53,6 +217,9 @@ type Admin struct {
 	Phone    string
 }
 
+// Preference holds what a user looks for in a match. The accepted genders
+// are GenderIds, stored in PreferenceGenders; GenderId keeps the first of
+// them for readers of the old single gender column.
 type Preference struct {
 	Id         uuid.UUID
 	ProfileId  uuid.UUID
@@ -61,8 +228,21 @@ type Preference struct {
 	MaxAge     int     `json:"max_age"  binding:"required" validate:"required"`
 	GenderId   int
 	Gender     Gender `gorm:"foreignKey:GenderId"`
+	GenderIds  []int  `gorm:"-"`
 	DesireCity string `json:"desirecity"  binding:"required" validate:"required"`
+	// MaxDistanceKm limits matches to users within this distance when both
+	// users have a location; zero matches on DesireCity instead.
+	MaxDistanceKm int `json:"max_distance_km"`
+}
+
+type PreferenceGenders struct {
+	Id           int        `json:"id" gorm:"primaryKey"`
+	PreferenceId uuid.UUID  `gorm:"uniqueIndex:idx_preference_genders"`
+	Preference   Preference `gorm:"foreignKey:PreferenceId;constraint:OnDelete:CASCADE"`
+	GenderId     int        `json:"gender_id" gorm:"uniqueIndex:idx_preference_genders"`
+	Gender       Gender     `gorm:"foreignKey:GenderId;constraint:OnDelete:CASCADE"`
 }
+
 type Address struct {
 	Id        uuid.UUID `gorm:"primaryKey;unique;not null"`
 	Country   string
@@ -78,7 +258,34 @@ type Profile struct {
 	UserId uuid.UUID
 	User   User `gorm:"foreignKey:UserId"`
 	Image  string
-	Age    int
+	// Age is the age computed when the profile was last updated. It is
+	// only read to backfill DateOfBirth; ages are derived from
+	// DateOfBirth at query time.
+	Age         int
+	DateOfBirth *time.Time `gorm:"type:date"`
+	Latitude    *float64   `gorm:"index:idx_profiles_location"`
+	Longitude   *float64   `gorm:"index:idx_profiles_location"`
+
+	Bio        string
+	Occupation string
+	Education  string
+	HeightCm   *int
+	// Drinking, Smoking and Exercise hold pb.Frequency values; 0 means the
+	// user has not answered.
+	Drinking int
+	Smoking  int
+	Exercise int
+}
+
+// ProfilePrompt is a question a user answered on their profile. Prompts are
+// shown in Position order.
+type ProfilePrompt struct {
+	Id        uuid.UUID `gorm:"primaryKey"`
+	ProfileId uuid.UUID `gorm:"index"`
+	Profile   Profile   `gorm:"foreignKey:ProfileId;constraint:OnDelete:CASCADE"`
+	Question  string
+	Answer    string
+	Position  int
 }
 
 type Images struct {
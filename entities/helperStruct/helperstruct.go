@@ -1,8 +1,12 @@
 package helperstruct
 
+import "time"
+
 type InterestHelper struct {
 	InterestId   int
 	InterestName string
+	CategoryId   int
+	CategoryName string
 }
 
 type GenderHelper struct {
@@ -11,25 +15,111 @@ type GenderHelper struct {
 }
 
 type FetchUser struct {
-	Age int
+	Age       int
+	Latitude  *float64
+	Longitude *float64
 }
 
 type FetchPreference struct {
-	MinAge     int
-	MaxAge     int
-	Gender     int
-	DesireCity string
+	MinAge        int
+	MaxAge        int
+	Genders       []int
+	DesireCity    string
+	MaxDistanceKm int
 }
 
 type Home struct {
-	Id      string
-	Name    string
-	Age     int
-	Gender  string
-	City    string
-	Country string
-	Images  []string
+	Id         string
+	ProfileId  string
+	Name       string
+	Age        int
+	Gender     string
+	City       string
+	Country    string
+	Images     []string
+	Interests  []string
+	DistanceKm *float64
+}
+
+// CandidateCard holds the images and interests shown on a candidate's card.
+type CandidateCard struct {
+	Images    []string
 	Interests []string
 }
 
+// Location is a point given in decimal degrees.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// CandidateQuery describes the profiles a viewer may be recommended. Age
+// bounds are inclusive and empty fields do not filter. With Reciprocal set,
+// only candidates whose own preference the viewer satisfies are returned;
+// candidates without a preference, or without preferred genders, accept
+// anyone on the missing criteria.
+// Distances from Origin are returned when it is set, and MaxDistanceKm
+// limits candidates to that radius around it. ExcludeLikedBy leaves out
+// candidates that user liked since LikedSince, or ever when it is zero.
+// Gender names are translated to the first of Locales that has a
+// translation.
+type CandidateQuery struct {
+	ProfileId      string
+	MinAge         int
+	MaxAge         int
+	GenderIds      []int
+	City           string
+	Country        string
+	ExcludeUserIds []string
+	ExcludeLikedBy string
+	LikedSince     time.Time
+	ExcludeBlocked bool
+	Reciprocal     bool
+	Origin         *Location
+	MaxDistanceKm  int
+	Locales        []string
+}
+
+// ProfileDetails is what a user shows others on their profile.
+type ProfileDetails struct {
+	Name       string
+	Bio        string
+	Occupation string
+	Education  string
+	HeightCm   *int
+	Drinking   int
+	Smoking    int
+	Exercise   int
+	Prompts    []Prompt `gorm:"-"`
+}
+
+type Prompt struct {
+	Question string
+	Answer   string
+}
 
+// ProfileUpdate holds the changes of one UpdateProfile call. Columns maps
+// profiles columns to their new values. Name and Prompts are only changed
+// when they are not nil.
+type ProfileUpdate struct {
+	Columns map[string]interface{}
+	Name    *string
+	Prompts *[]Prompt
+}
+
+// DataExport holds the rows stored about a user, keyed by what they
+// describe, such as "account" or "images". Each row maps column names to
+// values.
+type DataExport map[string][]map[string]interface{}
+
+// Entitlements is what a user may do under the plan they are on. ExpiresAt
+// is when their subscription ends and nil on the free plan.
+type Entitlements struct {
+	PlanId          string
+	PlanName        string
+	DailyLikes      int
+	DailySuperlikes int
+	DailyRewinds    int
+	SeeWhoLikedYou  bool
+	ExpiresAt       *time.Time
+}
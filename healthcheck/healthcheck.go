@@ -0,0 +1,99 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check reports whether a single dependency is usable.
+type Check func(ctx context.Context) error
+
+// Checker keeps the standard grpc.health.v1 service in sync with the state
+// of the service dependencies. The overall status ("") and the status of
+// the user service follow the result of the last round of checks.
+type Checker struct {
+	server   *health.Server
+	checks   map[string]Check
+	interval time.Duration
+	timeout  time.Duration
+}
+
+func NewChecker(interval time.Duration, checks map[string]Check) *Checker {
+	server := health.NewServer()
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	server.SetServingStatus(pb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	return &Checker{
+		server:   server,
+		checks:   checks,
+		interval: interval,
+		timeout:  interval / 2,
+	}
+}
+
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.server)
+}
+
+// Run checks the dependencies immediately and then every interval until ctx
+// is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	status := healthpb.HealthCheckResponse_SERVING
+	for name, check := range c.checks {
+		if err := check(ctx); err != nil {
+			slog.WarnContext(ctx, "health check failed", "check", name, "error", err)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	c.server.SetServingStatus("", status)
+	c.server.SetServingStatus(pb.UserService_ServiceDesc.ServiceName, status)
+}
+
+// Shutdown marks every service NOT_SERVING and ignores later check results,
+// so readiness probes fail while the server drains.
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
+
+// Probe asks the health service at addr for the overall status and returns
+// an error unless it is SERVING. It backs the -healthcheck mode of the
+// binary, since the runtime image has no grpc_health_probe.
+func Probe(addr string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if res.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("service is %s", res.Status)
+	}
+	return nil
+}
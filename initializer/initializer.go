@@ -1,19 +1,143 @@
 package initializer
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
 	"github.com/akshaybt001/DatingApp_UserService/concurrency"
+	"github.com/akshaybt001/DatingApp_UserService/config"
+	"github.com/akshaybt001/DatingApp_UserService/healthcheck"
 	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
 	"github.com/akshaybt001/DatingApp_UserService/internal/service"
 	"github.com/akshaybt001/DatingApp_UserService/internal/usecases"
+	"github.com/akshaybt001/DatingApp_UserService/metrics"
+	"github.com/akshaybt001/DatingApp_UserService/tracing"
+	"github.com/go-redis/redis"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gorm.io/gorm"
 )
 
-func Initializer(db *gorm.DB) *service.UserService {
+// App owns everything started by Initializer and tears it down in reverse
+// order on Shutdown.
+type App struct {
+	Service    *service.UserService
+	Health     *healthcheck.Checker
+	cron       *concurrency.CronJob
+	db         *gorm.DB
+	redis      *redis.Client
+	metrics    *http.Server
+	stopHealth context.CancelFunc
+	stopTrace  func(context.Context) error
+}
+
+func Initializer(db *gorm.DB, cfg config.Config) (*App, error) {
+	stopTrace, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, err
+	}
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	metrics.InstrumentRedis(redisClient)
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		redisClient.Close()
+		return nil, err
+	}
 	repo := adapters.NewUserAdapter(db)
-	usecase := usecases.NewUserUseCase(repo)
-	service := service.NewUserService(repo, usecase)
+	usecase, err := usecases.NewUserUseCase(repo, cfg.Minio)
+	if err != nil {
+		redisClient.Close()
+		return nil, err
+	}
+	service := service.NewUserService(repo, usecase, redisClient)
+	service.SetMaxInterests(cfg.MaxInterestsPerUser)
+	service.SetAccountPurgeGrace(cfg.AccountPurgeGrace)
+	service.SetSeenRecycling(cfg.SeenRecycleAfter, cfg.LikedRecycleAfter)
+	service.SetResetSeenOnPreferenceChange(cfg.ResetSeenOnPreferenceChange)
+	if migrated, err := service.MigrateSeenSets(context.Background()); err != nil {
+		slog.Warn("could not migrate seen users, they are recommended again", "error", err)
+	} else if migrated > 0 {
+		slog.Info("seen users migrated to sorted sets", "profiles", migrated)
+	}
 	concurrency := concurrency.NewCronJob(service, db)
 	concurrency.Start()
 
-	return service
+	checker := healthcheck.NewChecker(cfg.HealthCheckInterval, map[string]healthcheck.Check{
+		"postgres": func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+		"redis": func(ctx context.Context) error {
+			return redisClient.WithContext(ctx).Ping().Err()
+		},
+		"minio": usecase.CheckBucket,
+	})
+	ctx, stopHealth := context.WithCancel(context.Background())
+	go checker.Run(ctx)
+
+	var metricsServer *http.Server
+	if cfg.MetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		metricsServer = &http.Server{Addr: cfg.MetricsAddr, Handler: mux}
+		go func() {
+			slog.Info("metrics listening", "addr", cfg.MetricsAddr)
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("metrics listener stopped", "error", err)
+			}
+		}()
+	}
+
+	return &App{
+		Service:    service,
+		Health:     checker,
+		cron:       concurrency,
+		db:         db,
+		redis:      redisClient,
+		metrics:    metricsServer,
+		stopHealth: stopHealth,
+		stopTrace:  stopTrace,
+	}, nil
+}
+
+// Shutdown stops the health checks, the metrics listener and the cron jobs,
+// letting in-flight runs finish within ctx, and then closes the redis client
+// and the database pool. Pending spans are flushed last. Every step is attempted even if an earlier one fails.
+func (app *App) Shutdown(ctx context.Context) error {
+	app.Health.Shutdown()
+	app.stopHealth()
+	var errs []error
+	if app.metrics != nil {
+		if err := app.metrics.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stopping metrics listener: %w", err))
+		}
+	}
+	if err := app.cron.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := app.redis.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("closing redis: %w", err))
+	}
+	sqlDB, err := app.db.DB()
+	if err == nil {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("closing database: %w", err))
+	}
+	if err := app.stopTrace(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("flushing traces: %w", err))
+	}
+	return errors.Join(errs...)
 }
@@ -1,12 +1,50 @@
 package adapters
 
 import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/akshaybt001/DatingApp_UserService/entities"
 	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrUnknownInterest is returned by SetUserInterests when an interest id is
+// not in the catalog.
+var ErrUnknownInterest = errors.New("unknown interest")
+
+// ErrTooManyInterests is returned by UserAddInterestWithin when the profile
+// already has the maximum number of interests.
+var ErrTooManyInterests = errors.New("too many interests")
+
+// ErrUnknownGender is returned when a preference names a gender id that is
+// not in the catalog or has been deleted.
+var ErrUnknownGender = errors.New("unknown gender")
+
+// ErrNoPendingChange is returned by ConfirmAccountChange when the user has no
+// unexpired pending change with the given value.
+var ErrNoPendingChange = errors.New("no pending account change")
+
+// ErrNoLikesLeft is returned by DecrementLikeCount when the user has used
+// all of today's likes.
+var ErrNoLikesLeft = errors.New("no likes left")
+
+// ErrWrongCode is returned by ConfirmAccountChange when the code does not
+// match the pending change.
+var ErrWrongCode = errors.New("wrong confirmation code")
+
+// ErrTooManyAttempts is returned by ConfirmAccountChange once a pending
+// change has been given too many wrong codes.
+var ErrTooManyAttempts = errors.New("too many confirmation attempts")
+
 type UserAdapter struct {
 	DB *gorm.DB
 }
@@ -17,6 +55,28 @@ func NewUserAdapter(db *gorm.DB) *UserAdapter {
 	}
 }
 
+// contextAdapter is implemented by adapters that can bind their queries to a
+// request context.
+type contextAdapter interface {
+	WithContext(ctx context.Context) AdapterInterface
+}
+
+// WithContext binds adapter to ctx when it supports it and returns it
+// unchanged otherwise, as mocks do.
+func WithContext(adapter AdapterInterface, ctx context.Context) AdapterInterface {
+	if a, ok := adapter.(contextAdapter); ok {
+		return a.WithContext(ctx)
+	}
+	return adapter
+}
+
+// WithContext returns a copy of the adapter whose queries run with ctx.
+func (user *UserAdapter) WithContext(ctx context.Context) AdapterInterface {
+	return &UserAdapter{
+		DB: user.DB.WithContext(ctx),
+	}
+}
+
 func (user *UserAdapter) UserSignup(userData entities.User) (entities.User, error) {
 	var res entities.User
 	id := uuid.New()
@@ -29,7 +89,7 @@ func (user *UserAdapter) UserSignup(userData entities.User) (entities.User, erro
 
 func (user *UserAdapter) GetUserByEmail(email string) (entities.User, error) {
 	var res entities.User
-	selectQuery := `SELECT * FROM users WHERE email=?`
+	selectQuery := `SELECT * FROM users WHERE lower(email)=lower(?)`
 	if err := user.DB.Raw(selectQuery, email).Scan(&res).Error; err != nil {
 		return entities.User{}, err
 	}
@@ -45,9 +105,101 @@ func (user *UserAdapter) GetUserByPhone(phone string) (entities.User, error) {
 	return res, nil
 }
 
+// UpdateAccount changes the name of a user when name is not nil, replaces
+// the pending changes of the fields in pending and records audits, all in
+// one transaction. A replaced change starts over with no attempts.
+func (user *UserAdapter) UpdateAccount(userId string, name *string, pending []entities.PendingAccountChange, audits []entities.AccountAudit) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		if name != nil {
+			if err := tx.Exec(`UPDATE users SET name=? WHERE id=?`, *name, userId).Error; err != nil {
+				return err
+			}
+		}
+		upsertPending := `INSERT INTO pending_account_changes (user_id,field,value,expires_at,code_hash,attempts) VALUES (?,?,?,?,?,0) ON CONFLICT (user_id,field) DO UPDATE SET value=EXCLUDED.value, expires_at=EXCLUDED.expires_at, code_hash=EXCLUDED.code_hash, attempts=0`
+		for _, change := range pending {
+			if err := tx.Exec(upsertPending, userId, change.Field, change.Value, change.ExpiresAt, change.CodeHash).Error; err != nil {
+				return err
+			}
+		}
+		return addAccountAudits(tx, userId, audits)
+	})
+}
+
+// GetPendingAccountChanges returns the changes of a user that have not
+// expired.
+func (user *UserAdapter) GetPendingAccountChanges(userId string) ([]entities.PendingAccountChange, error) {
+	var res []entities.PendingAccountChange
+	selectQuery := `SELECT user_id, field, value, expires_at, attempts FROM pending_account_changes WHERE user_id=? AND expires_at > NOW() ORDER BY field`
+	if err := user.DB.Raw(selectQuery, userId).Scan(&res).Error; err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// accountColumns maps the fields that can be pending to their users column.
+var accountColumns = map[string]string{
+	"email": "email",
+	"phone": "phone",
+}
+
+// ConfirmAccountChange moves a pending change into the account and records
+// audit. The change must match an unexpired pending change of the user,
+// otherwise ErrNoPendingChange is returned, and change.CodeHash must match
+// the stored digest. A wrong code counts as an attempt and returns
+// ErrWrongCode; once maxAttempts wrong codes were given ErrTooManyAttempts
+// is returned until the change is requested again. A value taken by another
+// account in the meantime fails on the unique index.
+func (user *UserAdapter) ConfirmAccountChange(change entities.PendingAccountChange, maxAttempts int, audit entities.AccountAudit) error {
+	column, ok := accountColumns[change.Field]
+	if !ok {
+		return ErrNoPendingChange
+	}
+	// A wrong code is counted in a committed transaction, so the error is
+	// returned after it.
+	var wrongCode bool
+	err := user.DB.Transaction(func(tx *gorm.DB) error {
+		var pending entities.PendingAccountChange
+		selectPending := `SELECT user_id, field, value, code_hash, attempts FROM pending_account_changes WHERE user_id=? AND field=? AND value=? AND expires_at > NOW() FOR UPDATE`
+		if err := tx.Raw(selectPending, change.UserId, change.Field, change.Value).Scan(&pending).Error; err != nil {
+			return err
+		}
+		if pending.Field == "" {
+			return ErrNoPendingChange
+		}
+		if pending.Attempts >= maxAttempts {
+			return ErrTooManyAttempts
+		}
+		if subtle.ConstantTimeCompare([]byte(pending.CodeHash), []byte(change.CodeHash)) != 1 {
+			wrongCode = true
+			return tx.Exec(`UPDATE pending_account_changes SET attempts=attempts+1 WHERE user_id=? AND field=?`, change.UserId, change.Field).Error
+		}
+		if err := tx.Exec(`DELETE FROM pending_account_changes WHERE user_id=? AND field=?`, change.UserId, change.Field).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE users SET `+column+`=? WHERE id=?`, change.Value, change.UserId).Error; err != nil {
+			return err
+		}
+		return addAccountAudits(tx, change.UserId.String(), []entities.AccountAudit{audit})
+	})
+	if err == nil && wrongCode {
+		return ErrWrongCode
+	}
+	return err
+}
+
+func addAccountAudits(tx *gorm.DB, userId string, audits []entities.AccountAudit) error {
+	insertAudit := `INSERT INTO account_audits (id,user_id,field,event,old_value,new_value,created_at) VALUES (?,?,?,?,?,?,NOW())`
+	for _, audit := range audits {
+		if err := tx.Exec(insertAudit, uuid.New(), userId, audit.Field, audit.Event, audit.OldValue, audit.NewValue).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (user *UserAdapter) GetAdminByEmail(email string) (entities.Admin, error) {
 	var res entities.Admin
-	selectQuery := `SELECT * FROM admins WHERE email=?`
+	selectQuery := `SELECT * FROM admins WHERE lower(email)=lower(?)`
 	if err := user.DB.Raw(selectQuery, email).Scan(&res).Error; err != nil {
 		return entities.Admin{}, err
 	}
@@ -72,93 +224,287 @@ func (user *UserAdapter) GetProfileIdByUserId(userId string) (string, error) {
 	return profileId, nil
 }
 
+func (user *UserAdapter) GetProfileDetails(profileId string) (helperstruct.ProfileDetails, error) {
+	var res helperstruct.ProfileDetails
+	selectQuery := `SELECT u.name, p.bio, p.occupation, p.education, p.height_cm, p.drinking, p.smoking, p.exercise FROM profiles p JOIN users u ON u.id=p.user_id WHERE p.id=?`
+	if err := user.DB.Raw(selectQuery, profileId).Scan(&res).Error; err != nil {
+		return helperstruct.ProfileDetails{}, err
+	}
+	selectPrompts := `SELECT question, answer FROM profile_prompts WHERE profile_id=? ORDER BY position`
+	if err := user.DB.Raw(selectPrompts, profileId).Scan(&res.Prompts).Error; err != nil {
+		return helperstruct.ProfileDetails{}, err
+	}
+	return res, nil
+}
+
+// UpdateProfileDetails applies update to a profile in one transaction.
+// The keys of update.Columns are column names and must not come from
+// the client.
+func (user *UserAdapter) UpdateProfileDetails(profileId string, update helperstruct.ProfileUpdate) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		if update.Name != nil {
+			updateName := `UPDATE users SET name=? WHERE id=(SELECT user_id FROM profiles WHERE id=?)`
+			if err := tx.Exec(updateName, *update.Name, profileId).Error; err != nil {
+				return err
+			}
+		}
+		if len(update.Columns) > 0 {
+			columns := make([]string, 0, len(update.Columns))
+			for column := range update.Columns {
+				columns = append(columns, column)
+			}
+			sort.Strings(columns)
+			args := make([]interface{}, 0, len(columns)+1)
+			for i, column := range columns {
+				args = append(args, update.Columns[column])
+				columns[i] += `=?`
+			}
+			updateProfile := `UPDATE profiles SET ` + strings.Join(columns, `,`) + ` WHERE id=?`
+			if err := tx.Exec(updateProfile, append(args, profileId)...).Error; err != nil {
+				return err
+			}
+		}
+		if update.Prompts != nil {
+			if err := tx.Exec(`DELETE FROM profile_prompts WHERE profile_id=?`, profileId).Error; err != nil {
+				return err
+			}
+			insertPrompt := `INSERT INTO profile_prompts (id,profile_id,question,answer,position) VALUES (?,?,?,?,?)`
+			for i, prompt := range *update.Prompts {
+				if err := tx.Exec(insertPrompt, uuid.New(), profileId, prompt.Question, prompt.Answer, i).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// checkCatalogName returns gorm.ErrDuplicatedKey when an entry of table
+// other than id is named name, ignoring case and deleted entries. It stands
+// in for the unique catalog indexes, which cannot be built while a database
+// still holds duplicates.
+func checkCatalogName(db *gorm.DB, table, column, name string, id int) error {
+	var taken bool
+	selectQuery := `SELECT EXISTS (SELECT 1 FROM ` + table + ` WHERE lower(` + column + `)=lower(?) AND NOT is_deleted AND id<>?)`
+	if err := db.Raw(selectQuery, name, id).Scan(&taken).Error; err != nil {
+		return err
+	}
+	if taken {
+		return gorm.ErrDuplicatedKey
+	}
+	return nil
+}
+
+// AdminAddInterest adds an interest to the catalog. gorm.ErrDuplicatedKey is
+// returned when the name is taken.
 func (user *UserAdapter) AdminAddInterest(interest entities.Interests) error {
-	var id int
-	selectMaxId := `SELECT COALESCE(MAX(id),0) FROM interests`
-	if err := user.DB.Raw(selectMaxId).Scan(&id).Error; err != nil {
+	if err := checkCatalogName(user.DB, "interests", "interest", interest.Interest, 0); err != nil {
 		return err
 	}
-	insertInterest := `INSERT INTO interests (id,interest) VALUES ($1,$2)`
-	if err := user.DB.Exec(insertInterest, id+1, interest.Interest).Error; err != nil {
+	insertInterest := `INSERT INTO interests (interest,category_id) VALUES ($1,$2)`
+	if err := user.DB.Exec(insertInterest, interest.Interest, interest.CategoryId).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+// AdminAddGender adds a gender to the catalog. gorm.ErrDuplicatedKey is
+// returned when the name is taken.
 func (user *UserAdapter) AdminAddGender(gender entities.Gender) error {
-	var id int
-	selectMaxId := `SELECT COALESCE(MAX(id),0) FROM genders`
-	if err := user.DB.Raw(selectMaxId).Scan(&id).Error; err != nil {
+	if err := checkCatalogName(user.DB, "genders", "name", gender.Name, 0); err != nil {
 		return err
 	}
-	insertGender := `INSERT INTO genders (id,name) VALUES ($1,$2)`
-	if err := user.DB.Exec(insertGender, id+1, gender.Name).Error; err != nil {
+	insertGender := `INSERT INTO genders (name) VALUES ($1)`
+	if err := user.DB.Exec(insertGender, gender.Name).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (user *UserAdapter) GetInterestByName(interest string) (entities.Interests, error) {
-	var res entities.Interests
-	selectQuery := `SELECT * FROM interests WHERE interest=?`
-	if err := user.DB.Raw(selectQuery, interest).Scan(&res).Error; err != nil {
-		return entities.Interests{}, err
+// AdminUpdateInterest renames and recategorizes an interest.
+// gorm.ErrDuplicatedKey is returned when another interest has the name.
+func (user *UserAdapter) AdminUpdateInterest(interest entities.Interests) error {
+	if err := checkCatalogName(user.DB, "interests", "interest", interest.Interest, interest.Id); err != nil {
+		return err
 	}
-	return res, nil
+	updateInterest := `UPDATE interests SET interest=$1,category_id=$2 WHERE id=$3 AND NOT is_deleted`
+	if err := user.DB.Exec(updateInterest, interest.Interest, interest.CategoryId, interest.Id).Error; err != nil {
+		return err
+	}
+	return nil
 }
 
+// AdminUpdateGender renames a gender. gorm.ErrDuplicatedKey is returned
+// when another gender has the name.
+func (user *UserAdapter) AdminUpdateGender(gender entities.Gender) error {
+	if err := checkCatalogName(user.DB, "genders", "name", gender.Name, gender.Id); err != nil {
+		return err
+	}
+	updateGender := `UPDATE genders SET name=$1 WHERE id=$2 AND NOT is_deleted`
+	if err := user.DB.Exec(updateGender, gender.Name, gender.Id).Error; err != nil {
+		return err
+	}
+	return nil
+}
 
-func (user *UserAdapter) GetGenderByName(gender string) (entities.Gender, error) {
-	var res entities.Gender
-	selectQuery := `SELECT * FROM genders WHERE name=?`
-	if err := user.DB.Raw(selectQuery, gender).Scan(&res).Error; err != nil {
-		return entities.Gender{}, err
+// AdminDeleteInterest hides an interest from the catalog. Users who added
+// it keep it.
+func (user *UserAdapter) AdminDeleteInterest(id int) error {
+	deleteQuery := `UPDATE interests SET is_deleted=true WHERE id=$1`
+	if err := user.DB.Exec(deleteQuery, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (user *UserAdapter) AdminDeleteGender(id int) error {
+	deleteQuery := `UPDATE genders SET is_deleted=true WHERE id=$1`
+	if err := user.DB.Exec(deleteQuery, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// AdminMergeInterests moves every user of the source interest to the target
+// and deletes the source. Users who already have both keep a single link.
+func (user *UserAdapter) AdminMergeInterests(sourceId, targetId int) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		deleteQuery := `DELETE FROM user_interests s WHERE s.interest_id=$1 AND EXISTS (SELECT 1 FROM user_interests t WHERE t.profile_id=s.profile_id AND t.interest_id=$2)`
+		if err := tx.Exec(deleteQuery, sourceId, targetId).Error; err != nil {
+			return err
+		}
+		updateQuery := `UPDATE user_interests SET interest_id=$1 WHERE interest_id=$2`
+		if err := tx.Exec(updateQuery, targetId, sourceId).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE interests SET is_deleted=true WHERE id=$1`, sourceId).Error
+	})
+}
+
+// AdminMergeGenders is AdminMergeInterests for genders. The genders users
+// identify as and the genders their preferences accept are both moved.
+func (user *UserAdapter) AdminMergeGenders(sourceId, targetId int) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []struct{ name, owner string }{
+			{"user_genders", "profile_id"},
+			{"preference_genders", "preference_id"},
+		} {
+			deleteQuery := `DELETE FROM ` + table.name + ` s WHERE s.gender_id=$1 AND EXISTS (SELECT 1 FROM ` + table.name + ` t WHERE t.` + table.owner + `=s.` + table.owner + ` AND t.gender_id=$2)`
+			if err := tx.Exec(deleteQuery, sourceId, targetId).Error; err != nil {
+				return err
+			}
+			updateQuery := `UPDATE ` + table.name + ` SET gender_id=$1 WHERE gender_id=$2`
+			if err := tx.Exec(updateQuery, targetId, sourceId).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec(`UPDATE preferences SET gender_id=$1 WHERE gender_id=$2`, targetId, sourceId).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE genders SET is_deleted=true WHERE id=$1`, sourceId).Error
+	})
+}
+
+// translatedSQL is the name of the entity row with the given id in the first
+// locale that has a translation, or fallback when none has. It takes one
+// argument, the locales joined by localeList.
+func translatedSQL(entity, id, fallback string) string {
+	return `COALESCE((SELECT t.name FROM translations t, (SELECT ?::text AS list) l WHERE t.entity='` + entity + `' AND t.entity_id=` + id + ` AND strpos(l.list, ','||t.locale||',')>0 ORDER BY strpos(l.list, ','||t.locale||',') LIMIT 1), ` + fallback + `)`
+}
+
+// localeList joins locales for translatedSQL.
+func localeList(locales []string) string {
+	return "," + strings.Join(locales, ",") + ","
+}
+
+// AdminGetAllInterest lists the interests of categoryId, or of every
+// category when it is 0, grouped by category in catalog order.
+// Uncategorized interests come last. Names are translated to locales and
+// sorted in their translated form.
+func (user *UserAdapter) AdminGetAllInterest(categoryId int, locales []string) ([]helperstruct.InterestHelper, error) {
+	var res []helperstruct.InterestHelper
+	selectInterest := `SELECT interest_id, interest_name, category_id, category_name FROM (
+	SELECT i.id AS interest_id, ` + translatedSQL(entities.TranslationInterest, "i.id", "i.interest") + ` AS interest_name,
+	COALESCE(c.id,0) AS category_id, ` + translatedSQL(entities.TranslationInterestCategory, "c.id", "COALESCE(c.name,'')") + ` AS category_name, c.position
+	FROM interests i LEFT JOIN interest_categories c ON c.id=i.category_id
+	WHERE NOT i.is_deleted AND (?=0 OR i.category_id=?)) l
+	ORDER BY position NULLS LAST, lower(category_name), category_id, lower(interest_name), interest_id`
+	list := localeList(locales)
+	if err := user.DB.Raw(selectInterest, list, list, categoryId, categoryId).Scan(&res).Error; err != nil {
+		return []helperstruct.InterestHelper{}, err
 	}
 	return res, nil
 }
 
-func (user *UserAdapter) AdminUpdateInterest(interest entities.Interests) error {
-	updateInterest := `UPDATE interests SET interest=$1 WHERE id=$2`
-	if err := user.DB.Exec(updateInterest, interest.Interest, interest.Id).Error; err != nil {
+// AdminAddInterestCategory adds a category to the catalog.
+// gorm.ErrDuplicatedKey is returned when the name is taken.
+func (user *UserAdapter) AdminAddInterestCategory(category entities.InterestCategory) error {
+	if err := checkCatalogName(user.DB, "interest_categories", "name", category.Name, 0); err != nil {
+		return err
+	}
+	insertCategory := `INSERT INTO interest_categories (name,position) VALUES ($1,$2)`
+	if err := user.DB.Exec(insertCategory, category.Name, category.Position).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (user *UserAdapter) AdminUpdateGender(gender entities.Gender) error {
-	updateGender := `UPDATE genders SET name=$1 WHERE id=$2`
-	if err := user.DB.Exec(updateGender, gender.Name, gender.Id).Error; err != nil {
+// AdminUpdateInterestCategory renames and moves a category.
+// gorm.ErrDuplicatedKey is returned when another category has the name.
+func (user *UserAdapter) AdminUpdateInterestCategory(category entities.InterestCategory) error {
+	if err := checkCatalogName(user.DB, "interest_categories", "name", category.Name, category.Id); err != nil {
+		return err
+	}
+	updateCategory := `UPDATE interest_categories SET name=$1,position=$2 WHERE id=$3 AND NOT is_deleted`
+	if err := user.DB.Exec(updateCategory, category.Name, category.Position, category.Id).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (user *UserAdapter) AdminGetAllInterest() ([]entities.Interests, error) {
-	var res []entities.Interests
-	selectInterest := `SELECT * FROM interests`
-	if err := user.DB.Raw(selectInterest).Scan(&res).Error; err != nil {
-		return []entities.Interests{}, err
+// AdminDeleteInterestCategory hides a category. Its interests stay in the
+// catalog without a category.
+func (user *UserAdapter) AdminDeleteInterestCategory(id int) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE interests SET category_id=NULL WHERE category_id=$1`, id).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE interest_categories SET is_deleted=true WHERE id=$1`, id).Error
+	})
+}
+
+func (user *UserAdapter) AdminGetAllInterestCategories() ([]entities.InterestCategory, error) {
+	var res []entities.InterestCategory
+	selectCategories := `SELECT * FROM interest_categories WHERE NOT is_deleted ORDER BY position, lower(name), id`
+	if err := user.DB.Raw(selectCategories).Scan(&res).Error; err != nil {
+		return []entities.InterestCategory{}, err
+	}
+	return res, nil
+}
+
+func (user *UserAdapter) GetInterestCategoryById(id int) (entities.InterestCategory, error) {
+	var res entities.InterestCategory
+	selectCategory := `SELECT * FROM interest_categories WHERE id=? AND NOT is_deleted`
+	if err := user.DB.Raw(selectCategory, id).Scan(&res).Error; err != nil {
+		return entities.InterestCategory{}, err
 	}
 	return res, nil
 }
 
-func (user *UserAdapter) AdminGetAllGender() ([]entities.Gender, error) {
+// AdminGetAllGender lists the genders with their names translated to
+// locales.
+func (user *UserAdapter) AdminGetAllGender(locales []string) ([]entities.Gender, error) {
 	var res []entities.Gender
-	selectGender := `SELECT * FROM genders`
-	if err := user.DB.Raw(selectGender).Scan(&res).Error; err != nil {
+	selectGender := `SELECT g.id, ` + translatedSQL(entities.TranslationGender, "g.id", "g.name") + ` AS name FROM genders g WHERE NOT g.is_deleted ORDER BY g.id`
+	if err := user.DB.Raw(selectGender, localeList(locales)).Scan(&res).Error; err != nil {
 		return []entities.Gender{}, err
 	}
 	return res, nil
 }
 
 func (user *UserAdapter) UserAddInterest(interests entities.UserInterests) error {
-	var id int
-	selectMaxId := `SELECT COALESCE(MAX(id),0) FROM user_interests`
-	if err := user.DB.Raw(selectMaxId).Scan(&id).Error; err != nil {
-		return err
-	}
-	insertInterestQuery := `INSERT INTO user_interests(id,interest_id,profile_id) VALUES ($1,$2,$3)`
-	if err := user.DB.Exec(insertInterestQuery, id+1, interests.InterestId, interests.ProfileId).Error; err != nil {
+	insertInterestQuery := `INSERT INTO user_interests(interest_id,profile_id) VALUES ($1,$2)`
+	if err := user.DB.Exec(insertInterestQuery, interests.InterestId, interests.ProfileId).Error; err != nil {
 		return err
 	}
 	return nil
@@ -166,18 +512,84 @@ func (user *UserAdapter) UserAddInterest(interests entities.UserInterests) error
 
 // UserAddGender implements AdapterInterface.
 func (user *UserAdapter) UserAddGender(gender entities.UserGenders) error {
-	var id int
-	selectMaxId := `SELECT COALESCE(MAX(id),0) FROM user_genders`
-	if err := user.DB.Raw(selectMaxId).Scan(&id).Error; err != nil {
-		return err
-	}
-	insertInterestQuery := `INSERT INTO user_genders(id,gender_id,profile_id) VALUES ($1,$2,$3)`
-	if err := user.DB.Exec(insertInterestQuery, id+1, gender.GenderId, gender.ProfileId).Error; err != nil {
+	insertInterestQuery := `INSERT INTO user_genders(gender_id,profile_id) VALUES ($1,$2)`
+	if err := user.DB.Exec(insertInterestQuery, gender.GenderId, gender.ProfileId).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+// UserAddInterestWithin adds an interest to a profile that has fewer than
+// maxInterests, and returns ErrTooManyInterests otherwise. The profile row is
+// locked while counting, so concurrent adds cannot pass the limit together
+// or add the same interest twice; gorm.ErrDuplicatedKey is returned when the
+// profile already has the interest.
+func (user *UserAdapter) UserAddInterestWithin(interest entities.UserInterests, maxInterests int) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockProfile(tx, interest.ProfileId.String()); err != nil {
+			return err
+		}
+		var count int
+		if err := tx.Raw(`SELECT COUNT(*) FROM user_interests WHERE profile_id=$1`, interest.ProfileId).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxInterests {
+			return ErrTooManyInterests
+		}
+		var added int
+		if err := tx.Raw(`SELECT COUNT(*) FROM user_interests WHERE profile_id=$1 AND interest_id=$2`, interest.ProfileId, interest.InterestId).Scan(&added).Error; err != nil {
+			return err
+		}
+		if added > 0 {
+			return gorm.ErrDuplicatedKey
+		}
+		return NewUserAdapter(tx).UserAddInterest(interest)
+	})
+}
+
+// lockProfile locks the row of a profile until the transaction ends, which
+// serializes changes to the profile's interests.
+func lockProfile(tx *gorm.DB, profileId string) error {
+	var id uuid.UUID
+	return tx.Raw(`SELECT id FROM profiles WHERE id=$1 FOR UPDATE`, profileId).Scan(&id).Error
+}
+
+func (user *UserAdapter) CountUserInterests(profileId string) (int, error) {
+	var count int
+	selectCount := `SELECT COUNT(*) FROM user_interests WHERE profile_id=$1`
+	if err := user.DB.Raw(selectCount, profileId).Scan(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SetUserInterests replaces the interests of a profile with interestIds,
+// which must be distinct. Nothing changes when one of them is not in the
+// catalog; ErrUnknownInterest is returned instead.
+func (user *UserAdapter) SetUserInterests(profileId string, interestIds []int) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockProfile(tx, profileId); err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM user_interests WHERE profile_id=$1`, profileId).Error; err != nil {
+			return err
+		}
+		if len(interestIds) == 0 {
+			return nil
+		}
+		insertQuery := `INSERT INTO user_interests (interest_id,profile_id)
+		SELECT i.id, ? FROM interests i WHERE i.id IN ? AND NOT i.is_deleted`
+		res := tx.Exec(insertQuery, profileId, interestIds)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(interestIds)) {
+			return ErrUnknownInterest
+		}
+		return nil
+	})
+}
+
 func (user *UserAdapter) UserDeleteInterest(interest entities.UserInterests) error {
 	deleteInterestQuery := `DELETE FROM user_interests WHERE interest_id=$1 AND profile_id=$2`
 	if err := user.DB.Exec(deleteInterestQuery, interest.InterestId, interest.ProfileId).Error; err != nil {
@@ -186,26 +598,50 @@ func (user *UserAdapter) UserDeleteInterest(interest entities.UserInterests) err
 	return nil
 }
 
-func (user *UserAdapter) UserGetAllInterest(profileId string) ([]entities.Interests, error) {
+func (user *UserAdapter) UserGetAllInterest(profileId string, locales []string) ([]entities.Interests, error) {
 	var res []entities.Interests
-	selectInterestQueryUser := `SELECT i.id ,i.interest FROM interests i JOIN user_interests u ON u.interest_id=i.id WHERE profile_id=$1`
-	if err := user.DB.Raw(selectInterestQueryUser, profileId).Scan(&res).Error; err != nil {
+	selectInterestQueryUser := `SELECT i.id ,` + translatedSQL(entities.TranslationInterest, "i.id", "i.interest") + ` AS interest FROM interests i JOIN user_interests u ON u.interest_id=i.id WHERE profile_id=?`
+	if err := user.DB.Raw(selectInterestQueryUser, localeList(locales), profileId).Scan(&res).Error; err != nil {
 		return []entities.Interests{}, err
 	}
 	return res, nil
 }
 
-func (user *UserAdapter) UserGetAllGender(profileId string) (helperstruct.GenderHelper, error) {
-	var res helperstruct.GenderHelper
-	selectQueryUser := `SELECT g.id AS gender_id,g.name AS gender_name FROM genders g JOIN user_genders u ON u.gender_id=g.id WHERE profile_id=$1`
+func (user *UserAdapter) UpsertTranslation(translation entities.Translation) error {
+	upsertQuery := `INSERT INTO translations (entity,entity_id,locale,name) VALUES ($1,$2,$3,$4) ON CONFLICT (entity,entity_id,locale) DO UPDATE SET name=excluded.name`
+	if err := user.DB.Exec(upsertQuery, translation.Entity, translation.EntityId, translation.Locale, translation.Name).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (user *UserAdapter) DeleteTranslation(translation entities.Translation) error {
+	deleteQuery := `DELETE FROM translations WHERE entity=$1 AND entity_id=$2 AND locale=$3`
+	if err := user.DB.Exec(deleteQuery, translation.Entity, translation.EntityId, translation.Locale).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (user *UserAdapter) UserRemoveGender(gender entities.UserGenders) error {
+	deleteGenderQuery := `DELETE FROM user_genders WHERE gender_id=$1 AND profile_id=$2`
+	if err := user.DB.Exec(deleteGenderQuery, gender.GenderId, gender.ProfileId).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (user *UserAdapter) UserGetAllGender(profileId string) ([]helperstruct.GenderHelper, error) {
+	var res []helperstruct.GenderHelper
+	selectQueryUser := `SELECT g.id AS gender_id,g.name AS gender_name FROM genders g JOIN user_genders u ON u.gender_id=g.id WHERE profile_id=$1 ORDER BY g.id`
 	if err := user.DB.Raw(selectQueryUser, profileId).Scan(&res).Error; err != nil {
-		return helperstruct.GenderHelper{}, err
+		return []helperstruct.GenderHelper{}, err
 	}
 	return res, nil
 }
 
 func (user *UserAdapter) GetInterestById(id int) (helperstruct.InterestHelper, error) {
-	selectInterestQuery := `SELECT id AS interest_id , interest AS interest_name FROM interests WHERE id=?`
+	selectInterestQuery := `SELECT id AS interest_id , interest AS interest_name FROM interests WHERE id=? AND NOT is_deleted`
 	var res helperstruct.InterestHelper
 	if err := user.DB.Raw(selectInterestQuery, id).Scan(&res).Error; err != nil {
 		return helperstruct.InterestHelper{}, err
@@ -214,7 +650,7 @@ func (user *UserAdapter) GetInterestById(id int) (helperstruct.InterestHelper, e
 }
 
 func (user *UserAdapter) GetGenderById(id int) (helperstruct.GenderHelper, error) {
-	selectGenderQuery := `SELECT id AS gender_id, name AS gender_name FROM genders WHERE id=?`
+	selectGenderQuery := `SELECT id AS gender_id, name AS gender_name FROM genders WHERE id=? AND NOT is_deleted`
 	var res helperstruct.GenderHelper
 	if err := user.DB.Raw(selectGenderQuery, id).Scan(&res).Error; err != nil {
 		return helperstruct.GenderHelper{}, err
@@ -251,10 +687,37 @@ func (user *UserAdapter) UserAddAddress(req entities.Address) error {
 
 func (user *UserAdapter) UserAddPreference(req entities.Preference) error {
 	id := uuid.New()
-	insertQuery := `INSERT INTO preferences (id,min_age,max_age,gender_id,desire_city,profile_id) VALUES ($1,$2,$3,$4,$5,$6)`
-	if err := user.DB.Exec(insertQuery, id, req.MinAge, req.MaxAge, req.GenderId, req.DesireCity, req.ProfileId).Error; err != nil {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		insertQuery := `INSERT INTO preferences (id,min_age,max_age,gender_id,desire_city,max_distance_km,profile_id) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+		if err := tx.Exec(insertQuery, id, req.MinAge, req.MaxAge, req.GenderId, req.DesireCity, req.MaxDistanceKm, req.ProfileId).Error; err != nil {
+			return err
+		}
+		return setPreferenceGenders(tx, id, req.GenderIds)
+	})
+}
+
+// setPreferenceGenders replaces the accepted genders of a preference.
+// ErrUnknownGender is returned when one of the ids does not name a gender
+// that is in the catalog, so the caller's transaction is rolled back.
+func setPreferenceGenders(tx *gorm.DB, preferenceId uuid.UUID, genderIds []int) error {
+	if err := tx.Exec(`DELETE FROM preference_genders WHERE preference_id=$1`, preferenceId).Error; err != nil {
 		return err
 	}
+	if len(genderIds) == 0 {
+		return nil
+	}
+	distinct := make(map[int]bool, len(genderIds))
+	for _, id := range genderIds {
+		distinct[id] = true
+	}
+	insertQuery := `INSERT INTO preference_genders (preference_id,gender_id) SELECT ?, id FROM genders WHERE id IN ? AND NOT is_deleted ON CONFLICT DO NOTHING`
+	res := tx.Exec(insertQuery, preferenceId, genderIds)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != int64(len(distinct)) {
+		return ErrUnknownGender
+	}
 	return nil
 }
 
@@ -268,11 +731,17 @@ func (user *UserAdapter) UserEditAddress(req entities.Address) error {
 
 // UserEditPreference implements AdapterInterface.
 func (user *UserAdapter) UserEditPreference(req entities.Preference) error {
-	updateQuery := `UPDATE preferences SET min_age=$1,max_age=$2,gender_id=$3,desire_city=$4 WHERE profile_id=$5`
-	if err := user.DB.Exec(updateQuery, req.MinAge, req.MaxAge, req.GenderId, req.DesireCity, req.ProfileId).Error; err != nil {
-		return err
-	}
-	return nil
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		var id uuid.UUID
+		updateQuery := `UPDATE preferences SET min_age=$1,max_age=$2,gender_id=$3,desire_city=$4,max_distance_km=$5 WHERE profile_id=$6 RETURNING id`
+		if err := tx.Raw(updateQuery, req.MinAge, req.MaxAge, req.GenderId, req.DesireCity, req.MaxDistanceKm, req.ProfileId).Scan(&id).Error; err != nil {
+			return err
+		}
+		if id == uuid.Nil {
+			return nil
+		}
+		return setPreferenceGenders(tx, id, req.GenderIds)
+	})
 }
 
 func (user *UserAdapter) GetAddressByProfileId(id string) (entities.Address, error) {
@@ -300,6 +769,13 @@ func (user *UserAdapter) GetPreferenceByProfileId(profileId string) (entities.Pr
 	if err := user.DB.Raw(selectQuery, profileId).Scan(&res).Error; err != nil {
 		return entities.Preference{}, err
 	}
+	if res.Id == uuid.Nil {
+		return res, nil
+	}
+	selectGenders := `SELECT gender_id FROM preference_genders WHERE preference_id=? ORDER BY gender_id`
+	if err := user.DB.Raw(selectGenders, res.Id).Scan(&res.GenderIds).Error; err != nil {
+		return entities.Preference{}, err
+	}
 	return res, nil
 }
 
@@ -336,10 +812,23 @@ func (user *UserAdapter) GetProfilePic(profileId string) (string, error) {
 	return res, nil
 }
 
-func (user *UserAdapter) UpdateAge(age int, profileId string) error {
-	var res int
-	insertImageQuery := `UPDATE profiles SET age=$1 WHERE id=$2 RETURNING age`
-	if err := user.DB.Raw(insertImageQuery, age, profileId).Scan(&res).Error; err != nil {
+// ageSQL computes the current age of the profile aliased p from its date of
+// birth, so stored ages never go stale.
+func ageSQL(p string) string {
+	return `date_part('year', age(CURRENT_DATE, ` + p + `.date_of_birth))::int`
+}
+
+func (user *UserAdapter) UpdateDateOfBirth(dob time.Time, profileId string) error {
+	updateQuery := `UPDATE profiles SET date_of_birth=$1 WHERE id=$2`
+	if err := user.DB.Exec(updateQuery, dob, profileId).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (user *UserAdapter) UpdateLocation(location helperstruct.Location, profileId string) error {
+	updateQuery := `UPDATE profiles SET latitude=$1, longitude=$2 WHERE id=$3`
+	if err := user.DB.Exec(updateQuery, location.Latitude, location.Longitude, profileId).Error; err != nil {
 		return err
 	}
 	return nil
@@ -347,7 +836,7 @@ func (user *UserAdapter) UpdateAge(age int, profileId string) error {
 
 func (user *UserAdapter) GetAge(profileId string) (int, error) {
 	var res int
-	selectQuery := `SELECT age from profiles WHERE id=$1 AND age IS NOT NULL`
+	selectQuery := `SELECT ` + ageSQL("p") + ` from profiles p WHERE p.id=$1 AND p.date_of_birth IS NOT NULL`
 	if err := user.DB.Raw(selectQuery, profileId).Scan(&res).Error; err != nil {
 		return 0, err
 	}
@@ -356,7 +845,7 @@ func (user *UserAdapter) GetAge(profileId string) (int, error) {
 
 func (user *UserAdapter) FetchUser(profileId string) (helperstruct.FetchUser, error) {
 	var res helperstruct.FetchUser
-	selectQuery := `SELECT age from profiles WHERE id=$1`
+	selectQuery := `SELECT ` + ageSQL("p") + ` AS age, p.latitude, p.longitude from profiles p WHERE p.id=$1`
 	if err := user.DB.Raw(selectQuery, profileId).Scan(&res).Error; err != nil {
 		return helperstruct.FetchUser{}, err
 	}
@@ -365,26 +854,100 @@ func (user *UserAdapter) FetchUser(profileId string) (helperstruct.FetchUser, er
 
 func (user *UserAdapter) FetchPreference(profileId string) (helperstruct.FetchPreference, error) {
 	var res helperstruct.FetchPreference
-	selectQuery := `SELECT min_age,max_age,gender_id AS gender,desire_city FROM preferences WHERE profile_id=?`
+	selectQuery := `SELECT min_age,max_age,desire_city,max_distance_km FROM preferences WHERE profile_id=?`
 	if err := user.DB.Raw(selectQuery, profileId).Scan(&res).Error; err != nil {
 		return helperstruct.FetchPreference{}, err
 	}
+	selectGenders := `SELECT pg.gender_id FROM preference_genders pg JOIN preferences p ON p.id=pg.preference_id WHERE p.profile_id=? ORDER BY pg.gender_id`
+	if err := user.DB.Raw(selectGenders, profileId).Scan(&res.Genders).Error; err != nil {
+		return helperstruct.FetchPreference{}, err
+	}
 	return res, nil
 }
 
-func (user *UserAdapter) FetchInterests(id string) ([]string, error) {
+func (user *UserAdapter) FetchInterests(id string, locales []string) ([]string, error) {
 	var interests []string
-	selectQuery := `SELECT i.interest FROM interests i JOIN user_interests u ON u.interest_id=i.id WHERE profile_id=$1`
-	if err := user.DB.Raw(selectQuery, id).Scan(&interests).Error; err != nil {
+	selectQuery := `SELECT ` + translatedSQL(entities.TranslationInterest, "i.id", "i.interest") + ` FROM interests i JOIN user_interests u ON u.interest_id=i.id WHERE profile_id=?`
+	if err := user.DB.Raw(selectQuery, localeList(locales), id).Scan(&interests).Error; err != nil {
 		return nil, err
 	}
 	return interests, nil
 }
 
-func (user *UserAdapter) FetchUsers(maxAge, minAge, gender int, id string) ([]helperstruct.Home, error) {
+// distanceSQL is the haversine distance in kilometres between the rows
+// aliased from and to, which both carry latitude and longitude columns.
+// 12742 is the diameter of the Earth. The asin argument is clamped to 1, as
+// rounding can push it past 1 for near-antipodal points.
+func distanceSQL(from, to string) string {
+	return `(12742 * asin(least(1, sqrt(power(sin(radians(` + to + `.latitude - ` + from + `.latitude) / 2), 2) + cos(radians(` + from + `.latitude)) * cos(radians(` + to + `.latitude)) * power(sin(radians(` + to + `.longitude - ` + from + `.longitude) / 2), 2)))))`
+}
+
+// discoverableSQL keeps the candidates u that may be shown to a viewer:
+// visible users, and incognito users who liked the viewer. It takes the
+// visible mode, the incognito mode and the viewer's profile id.
+const discoverableSQL = `(u.discovery_mode=? OR u.discovery_mode=? AND EXISTS (SELECT 1 FROM user_likes l JOIN profiles vp ON vp.user_id=l.liked_id WHERE l.user_id=u.id AND vp.id=?))`
+
+// FetchCandidates returns the users matching q in a single query. The
+// viewer's own profile, deleted users and users hidden by their discovery
+// mode are never returned.
+func (user *UserAdapter) FetchCandidates(q helperstruct.CandidateQuery) ([]helperstruct.Home, error) {
 	var users []helperstruct.Home
-	selectQuery := `SELECT u.id ,u.name , p.age , g.name as gender, a.city , a.country ,p.image  FROM users u JOIN profiles p ON u.id=p.user_id JOIN user_genders ug ON p.id=ug.profile_id JOIN genders g ON g.id=ug.gender_id JOIN addresses a ON p.id=a.profile_id WHERE p.age>? AND p.age<? AND g.id=? AND p.id!=?`
-	if err := user.DB.Raw(selectQuery, maxAge, minAge, gender, id).Scan(&users).Error; err != nil {
+	distance := `NULL`
+	from := ` FROM users u JOIN profiles p ON u.id=p.user_id JOIN addresses a ON p.id=a.profile_id`
+	// The gender names in the select list take the first argument.
+	args := []interface{}{localeList(q.Locales)}
+	if q.Origin != nil {
+		// o is the origin, passed as a one row table so the distance
+		// expression only refers to columns.
+		distance = distanceSQL("o", "p")
+		from += ` CROSS JOIN (SELECT ?::float8 AS latitude, ?::float8 AS longitude) o`
+		args = append(args, q.Origin.Latitude, q.Origin.Longitude)
+	}
+	// Candidates with several genders are listed once with all of them.
+	gender := `(SELECT string_agg(` + translatedSQL(entities.TranslationGender, "g.id", "g.name") + `, ', ' ORDER BY g.id) FROM user_genders ug JOIN genders g ON g.id=ug.gender_id WHERE ug.profile_id=p.id)`
+	selectQuery := `SELECT u.id , p.id AS profile_id ,u.name , ` + ageSQL("p") + ` AS age , ` + gender + ` as gender, a.city , a.country ,p.image , ` + distance + ` AS distance_km` + from + ` WHERE u.deleted_at IS NULL AND ` + discoverableSQL + ` AND p.id!=? AND p.date_of_birth IS NOT NULL AND ` + ageSQL("p") + ` BETWEEN ? AND ?`
+	args = append(args, entities.DiscoveryVisible, entities.DiscoveryIncognito, q.ProfileId, q.ProfileId, q.MinAge, q.MaxAge)
+	if len(q.GenderIds) > 0 {
+		selectQuery += ` AND EXISTS (SELECT 1 FROM user_genders ug WHERE ug.profile_id=p.id AND ug.gender_id IN ?)`
+		args = append(args, q.GenderIds)
+	}
+	if q.City != "" {
+		selectQuery += ` AND lower(a.city)=lower(?)`
+		args = append(args, q.City)
+	}
+	if q.Country != "" {
+		selectQuery += ` AND lower(a.country)=lower(?)`
+		args = append(args, q.Country)
+	}
+	if q.Origin != nil && q.MaxDistanceKm > 0 {
+		// The bounding box lets the location index narrow the rows before
+		// the exact distance is computed.
+		minLat, maxLat, minLon, maxLon := helper.BoundingBox(*q.Origin, float64(q.MaxDistanceKm))
+		selectQuery += ` AND p.latitude BETWEEN ? AND ? AND p.longitude BETWEEN ? AND ? AND ` + distance + ` <= ?`
+		args = append(args, minLat, maxLat, minLon, maxLon, q.MaxDistanceKm)
+	}
+	if len(q.ExcludeUserIds) > 0 {
+		selectQuery += ` AND u.id NOT IN ?`
+		args = append(args, q.ExcludeUserIds)
+	}
+	if q.ExcludeLikedBy != "" {
+		selectQuery += ` AND NOT EXISTS (SELECT 1 FROM user_likes l WHERE l.user_id=? AND l.liked_id=u.id AND l.created_at >= ?)`
+		args = append(args, q.ExcludeLikedBy, q.LikedSince)
+	}
+	if q.ExcludeBlocked {
+		selectQuery += ` AND NOT u.is_blocked`
+	}
+	if q.Reciprocal {
+		// v is the viewer and cp the candidate's preference. Candidates
+		// are left out only when their preference rejects the viewer, so
+		// a missing preference or an empty gender set does not constrain.
+		// A candidate with a distance preference is matched on distance
+		// when both users have a location, and on city otherwise. Unknown
+		// values, such as a viewer without an address, reject the viewer.
+		selectQuery += ` AND NOT EXISTS (SELECT 1 FROM preferences cp JOIN profiles v ON v.id=? LEFT JOIN addresses va ON va.profile_id=v.id WHERE cp.profile_id=p.id AND NOT COALESCE(` + ageSQL("v") + ` BETWEEN cp.min_age AND cp.max_age AND (NOT EXISTS (SELECT 1 FROM preference_genders pg WHERE pg.preference_id=cp.id) OR EXISTS (SELECT 1 FROM preference_genders pg JOIN user_genders vg ON vg.gender_id=pg.gender_id WHERE pg.preference_id=cp.id AND vg.profile_id=v.id)) AND CASE WHEN cp.max_distance_km > 0 AND v.latitude IS NOT NULL AND p.latitude IS NOT NULL THEN ` + distanceSQL("v", "p") + ` <= cp.max_distance_km ELSE lower(cp.desire_city)=lower(va.city) END, false))`
+		args = append(args, q.ProfileId)
+	}
+	if err := user.DB.Raw(selectQuery, args...).Scan(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
@@ -399,27 +962,335 @@ func (user *UserAdapter) FetchImages(id string) ([]string, error) {
 	return images, nil
 }
 
+// FetchCandidateCards returns the card details of the given profiles in a
+// single query, keyed by profile id. Interests are translated to locales.
+func (user *UserAdapter) FetchCandidateCards(profileIds []string, locales []string) (map[string]helperstruct.CandidateCard, error) {
+	cards := make(map[string]helperstruct.CandidateCard, len(profileIds))
+	if len(profileIds) == 0 {
+		return cards, nil
+	}
+	var rows []struct {
+		ProfileId string
+		Images    string
+		Interests string
+	}
+	selectQuery := `SELECT p.id AS profile_id,
+	COALESCE((SELECT json_agg(i.file_name) FROM images i WHERE i.profile_id=p.id), '[]')::text AS images,
+	COALESCE((SELECT json_agg(` + translatedSQL(entities.TranslationInterest, "i.id", "i.interest") + `) FROM interests i JOIN user_interests ui ON ui.interest_id=i.id WHERE ui.profile_id=p.id), '[]')::text AS interests
+	FROM profiles p WHERE p.id IN ?`
+	if err := user.DB.Raw(selectQuery, localeList(locales), profileIds).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		var card helperstruct.CandidateCard
+		if err := json.Unmarshal([]byte(row.Images), &card.Images); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(row.Interests), &card.Interests); err != nil {
+			return nil, err
+		}
+		cards[row.ProfileId] = card
+	}
+	return cards, nil
+}
+
 func (user *UserAdapter) IsUserExist(id string) (bool, error) {
 	var count int
-	if err := user.DB.Raw(`SELECT COUNT(*) FROM users WHERE id=?`, id).Scan(&count).Error; err != nil {
+	if err := user.DB.Raw(`SELECT COUNT(*) FROM users WHERE id=? AND deleted_at IS NULL`, id).Scan(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
+// DecrementLikeCount uses up one like of a user, and returns ErrNoLikesLeft
+// without changing anything when none are left.
 func (user *UserAdapter) DecrementLikeCount(userId string) error {
-	query := "UPDATE users SET like_count = like_count - 1 WHERE id = ?"
-	if err := user.DB.Exec(query, userId).Error; err != nil {
-		return err
+	query := "UPDATE users SET like_count = like_count - 1 WHERE id = ? AND like_count > 0"
+	res := user.DB.Exec(query, userId)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNoLikesLeft
 	}
-
 	return nil
 }
 
-func (user *UserAdapter) UpdateSubscription(userId string, subscribed bool) error {
-	updateQuery := `UPDATE users SET is_subscribed=$1 WHERE id=$2`
-	if err := user.DB.Exec(updateQuery, subscribed, userId).Error; err != nil {
+// ResetLikeQuotas gives every user the daily likes of their plan: that of
+// their active, unexpired subscription or the free plan. It returns how
+// many users were reset.
+func (user *UserAdapter) ResetLikeQuotas() (int64, error) {
+	updateQuery := `UPDATE users u SET like_count=p.daily_likes FROM plans p WHERE p.id=COALESCE((SELECT s.plan_id FROM subscriptions s WHERE s.user_id=u.id AND s.status=? AND s.expires_at > NOW()), ?)`
+	res := user.DB.Exec(updateQuery, entities.SubscriptionActive, entities.PlanFree)
+	return res.RowsAffected, res.Error
+}
+
+func (user *UserAdapter) GetPlans() ([]entities.Plan, error) {
+	var plans []entities.Plan
+	selectQuery := `SELECT * FROM plans ORDER BY daily_likes, id`
+	if err := user.DB.Raw(selectQuery).Scan(&plans).Error; err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// GetEntitlements returns the entitlements of a user; PlanId is empty when
+// the user does not exist.
+func (user *UserAdapter) GetEntitlements(userId string) (helperstruct.Entitlements, error) {
+	var res helperstruct.Entitlements
+	selectQuery := `SELECT p.id AS plan_id, p.name AS plan_name, p.daily_likes, p.daily_superlikes, p.daily_rewinds, p.see_who_liked_you, s.expires_at FROM users u LEFT JOIN subscriptions s ON s.user_id=u.id AND s.status=? AND s.expires_at > NOW() JOIN plans p ON p.id=COALESCE(s.plan_id, ?) WHERE u.id=?`
+	if err := user.DB.Raw(selectQuery, entities.SubscriptionActive, entities.PlanFree, userId).Scan(&res).Error; err != nil {
+		return helperstruct.Entitlements{}, err
+	}
+	return res, nil
+}
+
+// StartSubscription makes sub the active subscription of its user,
+// cancelling the one it replaces. The user's remaining likes for the day
+// are raised to the daily likes of the new plan.
+func (user *UserAdapter) StartSubscription(sub entities.Subscription) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		return replaceSubscription(tx, sub.UserId.String(), &sub)
+	})
+}
+
+// CancelSubscription ends the active subscription of a user at once and
+// caps their remaining likes at those of the free plan.
+func (user *UserAdapter) CancelSubscription(userId string) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		return replaceSubscription(tx, userId, nil)
+	})
+}
+
+// ApplySubscriptionEvent adds event to the ledger and, unless a newer event
+// of the user has been applied, makes sub their subscription; a nil sub
+// ends it. It returns the outcome of the event. The user's row is locked,
+// so the events of one user are applied one at a time.
+func (user *UserAdapter) ApplySubscriptionEvent(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error) {
+	outcome := entities.SubscriptionEventApplied
+	err := user.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		if err := tx.Raw(`SELECT id FROM users WHERE id=? FOR UPDATE`, event.UserId).Scan(&ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		var newer int
+		selectQuery := `SELECT COUNT(*) FROM subscription_events WHERE user_id=? AND outcome=? AND (occurred_at, id) > (?, ?)`
+		if err := tx.Raw(selectQuery, event.UserId, entities.SubscriptionEventApplied, event.OccurredAt, event.Id).Scan(&newer).Error; err != nil {
+			return err
+		}
+		if newer > 0 {
+			outcome = entities.SubscriptionEventStale
+		}
+		event.Outcome = outcome
+		res := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&event)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			outcome = entities.SubscriptionEventDuplicate
+			return nil
+		}
+		if outcome == entities.SubscriptionEventStale {
+			return nil
+		}
+		return replaceSubscription(tx, event.UserId.String(), sub)
+	})
+	return outcome, err
+}
+
+// replaceSubscription cancels the active subscription of a user and starts
+// sub in its place, or moves the user to the free plan when sub is nil.
+func replaceSubscription(tx *gorm.DB, userId string, sub *entities.Subscription) error {
+	updateQuery := `UPDATE subscriptions SET status=? WHERE user_id=? AND status=?`
+	if err := tx.Exec(updateQuery, entities.SubscriptionCancelled, userId, entities.SubscriptionActive).Error; err != nil {
 		return err
 	}
-	return nil
+	if sub == nil {
+		return downgradeUsers(tx, []string{userId})
+	}
+	if err := tx.Omit(clause.Associations).Create(sub).Error; err != nil {
+		return err
+	}
+	updateQuery = `UPDATE users SET is_subscribed=true, like_count=GREATEST(like_count, (SELECT daily_likes FROM plans WHERE id=?)) WHERE id=?`
+	return tx.Exec(updateQuery, sub.PlanId, userId).Error
+}
+
+// ExpireSubscriptions marks active subscriptions that have run out as
+// expired, moves their users to the free plan and returns how many
+// subscriptions expired.
+func (user *UserAdapter) ExpireSubscriptions() (int64, error) {
+	var userIds []string
+	err := user.DB.Transaction(func(tx *gorm.DB) error {
+		updateQuery := `UPDATE subscriptions SET status=? WHERE status=? AND expires_at <= NOW() RETURNING user_id::text`
+		if err := tx.Raw(updateQuery, entities.SubscriptionExpired, entities.SubscriptionActive).Scan(&userIds).Error; err != nil {
+			return err
+		}
+		if len(userIds) == 0 {
+			return nil
+		}
+		return downgradeUsers(tx, userIds)
+	})
+	return int64(len(userIds)), err
+}
+
+// downgradeUsers clears the subscribed flag of users left without an active
+// subscription and caps their remaining likes at those of the free plan.
+// Users coming from a plan with unlimited likes get the free plan's likes.
+func downgradeUsers(tx *gorm.DB, userIds []string) error {
+	updateQuery := `UPDATE users u SET is_subscribed=false, like_count=CASE WHEN u.like_count < 0 THEN p.daily_likes ELSE LEAST(u.like_count, p.daily_likes) END FROM plans p WHERE p.id=? AND u.id IN ?`
+	return tx.Exec(updateQuery, entities.PlanFree, userIds).Error
+}
+
+// SoftDeleteUser marks a user as deleted and returns when that happened.
+// A user that was already deleted keeps the original time.
+func (user *UserAdapter) SoftDeleteUser(userId string) (time.Time, error) {
+	var deletedAt time.Time
+	updateQuery := `UPDATE users SET deleted_at=COALESCE(deleted_at, NOW()) WHERE id=? RETURNING deleted_at`
+	if err := user.DB.Raw(updateQuery, userId).Scan(&deletedAt).Error; err != nil {
+		return time.Time{}, err
+	}
+	return deletedAt, nil
+}
+
+// GetUsersToPurge returns the ids of users deleted before deletedBefore.
+func (user *UserAdapter) GetUsersToPurge(deletedBefore time.Time) ([]string, error) {
+	var ids []string
+	selectQuery := `SELECT id FROM users WHERE deleted_at < ? ORDER BY deleted_at`
+	if err := user.DB.Raw(selectQuery, deletedBefore).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FetchUserImages returns the stored image URLs of every profile of a user.
+func (user *UserAdapter) FetchUserImages(userId string) ([]string, error) {
+	var images []string
+	selectQuery := `SELECT i.file_name FROM images i JOIN profiles p ON i.profile_id=p.id WHERE p.user_id=?`
+	if err := user.DB.Raw(selectQuery, userId).Scan(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// purgeQueries delete everything stored about a user, children before the
+// rows they reference; ? is the user id.
+var purgeQueries = []string{
+	`DELETE FROM profile_prompts WHERE profile_id IN (SELECT id FROM profiles WHERE user_id=?)`,
+	`DELETE FROM preference_genders WHERE preference_id IN (SELECT pr.id FROM preferences pr JOIN profiles p ON p.id=pr.profile_id WHERE p.user_id=?)`,
+	`DELETE FROM preferences WHERE profile_id IN (SELECT id FROM profiles WHERE user_id=?)`,
+	`DELETE FROM user_interests WHERE profile_id IN (SELECT id FROM profiles WHERE user_id=?)`,
+	`DELETE FROM user_genders WHERE profile_id IN (SELECT id FROM profiles WHERE user_id=?)`,
+	`DELETE FROM images WHERE profile_id IN (SELECT id FROM profiles WHERE user_id=?)`,
+	`DELETE FROM addresses WHERE profile_id IN (SELECT id FROM profiles WHERE user_id=?)`,
+	`DELETE FROM profiles WHERE user_id=?`,
+	`DELETE FROM pending_account_changes WHERE user_id=?`,
+	`DELETE FROM account_audits WHERE user_id=?`,
+	`DELETE FROM user_likes WHERE ? IN (user_id, liked_id)`,
+	`DELETE FROM subscriptions WHERE user_id=?`,
+	`DELETE FROM subscription_events WHERE user_id=?`,
+	`DELETE FROM users WHERE id=?`,
+}
+
+// PurgeUser deletes a soft deleted user and all of their data in one
+// transaction. Users that are not deleted are left alone.
+func (user *UserAdapter) PurgeUser(userId string) error {
+	return user.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		if err := tx.Raw(`SELECT id FROM users WHERE id=? AND deleted_at IS NOT NULL FOR UPDATE`, userId).Scan(&ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		for _, query := range purgeQueries {
+			if err := tx.Exec(query, userId).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// exportQueries select what is stored about a user for ExportUserData; ? is
+// the user id. Password hashes are left out.
+var exportQueries = []struct {
+	name  string
+	query string
+}{
+	{"account", `SELECT id::text, name, email, phone, is_blocked, report_count, like_count, is_subscribed, discovery_mode, created_at, deleted_at FROM users WHERE id=?`},
+	{"profile", `SELECT id::text, image, date_of_birth, latitude, longitude, bio, occupation, education, height_cm, drinking, smoking, exercise FROM profiles WHERE user_id=?`},
+	{"prompts", `SELECT pp.question, pp.answer, pp.position FROM profile_prompts pp JOIN profiles p ON p.id=pp.profile_id WHERE p.user_id=? ORDER BY pp.position`},
+	{"addresses", `SELECT a.country, a.state, a.district, a.city FROM addresses a JOIN profiles p ON p.id=a.profile_id WHERE p.user_id=?`},
+	{"preferences", `SELECT pr.min_age, pr.max_age, pr.desire_city, pr.max_distance_km, (SELECT string_agg(g.name, ', ' ORDER BY g.id) FROM preference_genders pg JOIN genders g ON g.id=pg.gender_id WHERE pg.preference_id=pr.id) AS genders FROM preferences pr JOIN profiles p ON p.id=pr.profile_id WHERE p.user_id=?`},
+	{"interests", `SELECT i.id, i.interest FROM user_interests ui JOIN interests i ON i.id=ui.interest_id JOIN profiles p ON p.id=ui.profile_id WHERE p.user_id=? ORDER BY i.id`},
+	{"genders", `SELECT g.id, g.name FROM user_genders ug JOIN genders g ON g.id=ug.gender_id JOIN profiles p ON p.id=ug.profile_id WHERE p.user_id=? ORDER BY g.id`},
+	{"images", `SELECT i.file_name FROM images i JOIN profiles p ON p.id=i.profile_id WHERE p.user_id=?`},
+	{"pending_account_changes", `SELECT field, value, expires_at FROM pending_account_changes WHERE user_id=? ORDER BY field`},
+	{"subscriptions", `SELECT plan_id, status, started_at, expires_at, payment_ref FROM subscriptions WHERE user_id=? ORDER BY started_at`},
+	{"subscription_events", `SELECT id, type, plan_id, expires_at, payment_ref, occurred_at, outcome FROM subscription_events WHERE user_id=? ORDER BY occurred_at, id`},
+	{"likes", `SELECT liked_id::text, created_at FROM user_likes WHERE user_id=? ORDER BY created_at`},
+	{"account_audits", `SELECT field, event, old_value, new_value, created_at FROM account_audits WHERE user_id=? ORDER BY created_at`},
+}
+
+// ExportUserData returns everything stored about a user.
+func (user *UserAdapter) ExportUserData(userId string) (helperstruct.DataExport, error) {
+	res := make(helperstruct.DataExport, len(exportQueries))
+	for _, q := range exportQueries {
+		rows := []map[string]interface{}{}
+		if err := user.DB.Raw(q.query, userId).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		res[q.name] = rows
+	}
+	return res, nil
+}
+
+func (user *UserAdapter) SetDiscoveryMode(userId string, mode int) error {
+	updateQuery := `UPDATE users SET discovery_mode=? WHERE id=?`
+	return user.DB.Exec(updateQuery, mode, userId).Error
+}
+
+// RecordLike stores that userId liked likedId. Recording a like twice keeps
+// the first.
+func (user *UserAdapter) RecordLike(userId, likedId string) error {
+	insertQuery := `INSERT INTO user_likes (user_id,liked_id,created_at) VALUES (?,?,NOW()) ON CONFLICT DO NOTHING`
+	return user.DB.Exec(insertQuery, userId, likedId).Error
+}
+
+// ImportLikes stores likes with the time they were made and returns how
+// many were new. Likes naming a user that does not exist are skipped, so
+// one purged account does not fail the whole import.
+func (user *UserAdapter) ImportLikes(likes []entities.UserLike) (int, error) {
+	imported := 0
+	err := user.DB.Transaction(func(tx *gorm.DB) error {
+		insertQuery := `INSERT INTO user_likes (user_id,liked_id,created_at) SELECT u.id, l.id, ? FROM users u, users l WHERE u.id=? AND l.id=? ON CONFLICT DO NOTHING`
+		for _, like := range likes {
+			res := tx.Exec(insertQuery, like.CreatedAt, like.UserId, like.LikedId)
+			if res.Error != nil {
+				return res.Error
+			}
+			imported += int(res.RowsAffected)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+func (user *UserAdapter) RemoveLike(userId, likedId string) error {
+	deleteQuery := `DELETE FROM user_likes WHERE user_id=? AND liked_id=?`
+	return user.DB.Exec(deleteQuery, userId, likedId).Error
+}
+
+func (user *UserAdapter) HasLiked(userId, likedId string) (bool, error) {
+	var count int
+	if err := user.DB.Raw(`SELECT COUNT(*) FROM user_likes WHERE user_id=? AND liked_id=?`, userId, likedId).Scan(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
 }
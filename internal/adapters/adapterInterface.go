@@ -1,6 +1,8 @@
 package adapters
 
 import (
+	"time"
+
 	"github.com/akshaybt001/DatingApp_UserService/entities"
 	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
 )
@@ -10,21 +12,48 @@ type AdapterInterface interface {
 	GetUserByEmail(email string) (entities.User, error)
 	GetUserByPhone(phone string) (entities.User, error)
 	GetAdminByEmail(email string) (entities.Admin, error)
+	UpdateAccount(userId string, name *string, pending []entities.PendingAccountChange, audits []entities.AccountAudit) error
+	GetPendingAccountChanges(userId string) ([]entities.PendingAccountChange, error)
+	ConfirmAccountChange(change entities.PendingAccountChange, maxAttempts int, audit entities.AccountAudit) error
+	SoftDeleteUser(userId string) (time.Time, error)
+	GetUsersToPurge(deletedBefore time.Time) ([]string, error)
+	FetchUserImages(userId string) ([]string, error)
+	PurgeUser(userId string) error
+	ExportUserData(userId string) (helperstruct.DataExport, error)
+	SetDiscoveryMode(userId string, mode int) error
+	RecordLike(userId, likedId string) error
+	RemoveLike(userId, likedId string) error
+	ImportLikes(likes []entities.UserLike) (int, error)
+	HasLiked(userId, likedId string) (bool, error)
 	CreateProfile(userID string) error
 	GetProfileIdByUserId(userId string) (string, error)
+	GetProfileDetails(profileId string) (helperstruct.ProfileDetails, error)
+	UpdateProfileDetails(profileId string, update helperstruct.ProfileUpdate) error
 
 	AdminAddInterest(entities.Interests) error
-	GetInterestByName(interest string) (entities.Interests, error)
 	AdminUpdateInterest(entities.Interests) error
-	AdminGetAllInterest() ([]entities.Interests, error)
-	GetGenderByName(gender string) (entities.Gender, error)
+	AdminGetAllInterest(categoryId int, locales []string) ([]helperstruct.InterestHelper, error)
+	AdminAddInterestCategory(entities.InterestCategory) error
+	AdminUpdateInterestCategory(entities.InterestCategory) error
+	AdminDeleteInterestCategory(id int) error
+	AdminGetAllInterestCategories() ([]entities.InterestCategory, error)
+	GetInterestCategoryById(id int) (entities.InterestCategory, error)
 	AdminAddGender(entities.Gender) error
 	AdminUpdateGender(entities.Gender) error
-	AdminGetAllGender() ([]entities.Gender, error)
+	AdminDeleteInterest(id int) error
+	AdminDeleteGender(id int) error
+	AdminMergeInterests(sourceId, targetId int) error
+	AdminMergeGenders(sourceId, targetId int) error
+	AdminGetAllGender(locales []string) ([]entities.Gender, error)
+	UpsertTranslation(entities.Translation) error
+	DeleteTranslation(entities.Translation) error
 
 	UserAddInterest(interest entities.UserInterests) error
 	UserDeleteInterest(interest entities.UserInterests) error
-	UserGetAllInterest(profileId string) ([]entities.Interests, error)
+	CountUserInterests(profileId string) (int, error)
+	UserAddInterestWithin(interest entities.UserInterests, maxInterests int) error
+	SetUserInterests(profileId string, interestIds []int) error
+	UserGetAllInterest(profileId string, locales []string) ([]entities.Interests, error)
 	GetInterestById(id int) (helperstruct.InterestHelper, error)
 	GetUserInterestById(profileId string, interestId int) (entities.UserInterests, error)
 	UserAddAddress(entities.Address) error
@@ -34,22 +63,31 @@ type AdapterInterface interface {
 	GetGenderByProfileId(id string) (entities.UserGenders, error)
 	GetUserGenderById(profileId string, genderId int) (entities.UserGenders, error)
 	UserAddGender(gender entities.UserGenders) error
-	UserGetAllGender(profileId string) (helperstruct.GenderHelper, error)
+	UserRemoveGender(gender entities.UserGenders) error
+	UserGetAllGender(profileId string) ([]helperstruct.GenderHelper, error)
 	GetPreferenceByProfileId(profileId string) (entities.Preference, error)
 	UserAddPreference(entities.Preference) error
 	UserEditPreference(entities.Preference) error
 	GetUserById(userId string) (entities.User, error)
 	UploadProfileImage(Image, ProfileId string) (string, error)
 	GetProfilePic(string) (string, error)
-	UpdateAge(age int, profileId string) error
+	UpdateDateOfBirth(dob time.Time, profileId string) error
+	UpdateLocation(location helperstruct.Location, profileId string) error
 	GetAge(profileId string) (int, error)
 	FetchUser(profile string) (helperstruct.FetchUser, error)
 	FetchPreference(string) (helperstruct.FetchPreference, error)
-	FetchInterests(id string) ([]string, error)
-	FetchUsers(maxAge, minAge, gender int, id string) ([]helperstruct.Home, error)
+	FetchInterests(id string, locales []string) ([]string, error)
+	FetchCandidates(q helperstruct.CandidateQuery) ([]helperstruct.Home, error)
 	FetchImages(id string) ([]string, error)
+	FetchCandidateCards(profileIds []string, locales []string) (map[string]helperstruct.CandidateCard, error)
 
 	IsUserExist(id string) (bool, error)
 	DecrementLikeCount(userId string) error
-	UpdateSubscription(userId string, subscribed bool) error
+	ResetLikeQuotas() (int64, error)
+	GetPlans() ([]entities.Plan, error)
+	GetEntitlements(userId string) (helperstruct.Entitlements, error)
+	StartSubscription(sub entities.Subscription) error
+	CancelSubscription(userId string) error
+	ApplySubscriptionEvent(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error)
+	ExpireSubscriptions() (int64, error)
 }
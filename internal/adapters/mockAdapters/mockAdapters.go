@@ -6,6 +6,7 @@ package mock_adapters
 
 import (
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/akshaybt001/DatingApp_UserService/entities"
 	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
@@ -63,34 +64,133 @@ func (mr *MockAdapterInterfaceMockRecorder) AdminAddInterest(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAddInterest", reflect.TypeOf((*MockAdapterInterface)(nil).AdminAddInterest), arg0)
 }
 
+// AdminAddInterestCategory mocks base method.
+func (m *MockAdapterInterface) AdminAddInterestCategory(arg0 entities.InterestCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAddInterestCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminAddInterestCategory indicates an expected call of AdminAddInterestCategory.
+func (mr *MockAdapterInterfaceMockRecorder) AdminAddInterestCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAddInterestCategory", reflect.TypeOf((*MockAdapterInterface)(nil).AdminAddInterestCategory), arg0)
+}
+
+// AdminDeleteGender mocks base method.
+func (m *MockAdapterInterface) AdminDeleteGender(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDeleteGender", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDeleteGender indicates an expected call of AdminDeleteGender.
+func (mr *MockAdapterInterfaceMockRecorder) AdminDeleteGender(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDeleteGender", reflect.TypeOf((*MockAdapterInterface)(nil).AdminDeleteGender), id)
+}
+
+// AdminDeleteInterest mocks base method.
+func (m *MockAdapterInterface) AdminDeleteInterest(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDeleteInterest", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDeleteInterest indicates an expected call of AdminDeleteInterest.
+func (mr *MockAdapterInterfaceMockRecorder) AdminDeleteInterest(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDeleteInterest", reflect.TypeOf((*MockAdapterInterface)(nil).AdminDeleteInterest), id)
+}
+
+// AdminDeleteInterestCategory mocks base method.
+func (m *MockAdapterInterface) AdminDeleteInterestCategory(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDeleteInterestCategory", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDeleteInterestCategory indicates an expected call of AdminDeleteInterestCategory.
+func (mr *MockAdapterInterfaceMockRecorder) AdminDeleteInterestCategory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDeleteInterestCategory", reflect.TypeOf((*MockAdapterInterface)(nil).AdminDeleteInterestCategory), id)
+}
+
 // AdminGetAllGender mocks base method.
-func (m *MockAdapterInterface) AdminGetAllGender() ([]entities.Gender, error) {
+func (m *MockAdapterInterface) AdminGetAllGender(locales []string) ([]entities.Gender, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminGetAllGender")
+	ret := m.ctrl.Call(m, "AdminGetAllGender", locales)
 	ret0, _ := ret[0].([]entities.Gender)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AdminGetAllGender indicates an expected call of AdminGetAllGender.
-func (mr *MockAdapterInterfaceMockRecorder) AdminGetAllGender() *gomock.Call {
+func (mr *MockAdapterInterfaceMockRecorder) AdminGetAllGender(locales interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminGetAllGender", reflect.TypeOf((*MockAdapterInterface)(nil).AdminGetAllGender))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminGetAllGender", reflect.TypeOf((*MockAdapterInterface)(nil).AdminGetAllGender), locales)
 }
 
 // AdminGetAllInterest mocks base method.
-func (m *MockAdapterInterface) AdminGetAllInterest() ([]entities.Interests, error) {
+func (m *MockAdapterInterface) AdminGetAllInterest(categoryId int, locales []string) ([]helperstruct.InterestHelper, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminGetAllInterest")
-	ret0, _ := ret[0].([]entities.Interests)
+	ret := m.ctrl.Call(m, "AdminGetAllInterest", categoryId, locales)
+	ret0, _ := ret[0].([]helperstruct.InterestHelper)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AdminGetAllInterest indicates an expected call of AdminGetAllInterest.
-func (mr *MockAdapterInterfaceMockRecorder) AdminGetAllInterest() *gomock.Call {
+func (mr *MockAdapterInterfaceMockRecorder) AdminGetAllInterest(categoryId, locales interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminGetAllInterest", reflect.TypeOf((*MockAdapterInterface)(nil).AdminGetAllInterest), categoryId, locales)
+}
+
+// AdminGetAllInterestCategories mocks base method.
+func (m *MockAdapterInterface) AdminGetAllInterestCategories() ([]entities.InterestCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminGetAllInterestCategories")
+	ret0, _ := ret[0].([]entities.InterestCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminGetAllInterestCategories indicates an expected call of AdminGetAllInterestCategories.
+func (mr *MockAdapterInterfaceMockRecorder) AdminGetAllInterestCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminGetAllInterestCategories", reflect.TypeOf((*MockAdapterInterface)(nil).AdminGetAllInterestCategories))
+}
+
+// AdminMergeGenders mocks base method.
+func (m *MockAdapterInterface) AdminMergeGenders(sourceId, targetId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminMergeGenders", sourceId, targetId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminMergeGenders indicates an expected call of AdminMergeGenders.
+func (mr *MockAdapterInterfaceMockRecorder) AdminMergeGenders(sourceId, targetId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminGetAllInterest", reflect.TypeOf((*MockAdapterInterface)(nil).AdminGetAllInterest))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminMergeGenders", reflect.TypeOf((*MockAdapterInterface)(nil).AdminMergeGenders), sourceId, targetId)
+}
+
+// AdminMergeInterests mocks base method.
+func (m *MockAdapterInterface) AdminMergeInterests(sourceId, targetId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminMergeInterests", sourceId, targetId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminMergeInterests indicates an expected call of AdminMergeInterests.
+func (mr *MockAdapterInterfaceMockRecorder) AdminMergeInterests(sourceId, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminMergeInterests", reflect.TypeOf((*MockAdapterInterface)(nil).AdminMergeInterests), sourceId, targetId)
 }
 
 // AdminUpdateGender mocks base method.
@@ -121,6 +221,78 @@ func (mr *MockAdapterInterfaceMockRecorder) AdminUpdateInterest(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUpdateInterest", reflect.TypeOf((*MockAdapterInterface)(nil).AdminUpdateInterest), arg0)
 }
 
+// AdminUpdateInterestCategory mocks base method.
+func (m *MockAdapterInterface) AdminUpdateInterestCategory(arg0 entities.InterestCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminUpdateInterestCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminUpdateInterestCategory indicates an expected call of AdminUpdateInterestCategory.
+func (mr *MockAdapterInterfaceMockRecorder) AdminUpdateInterestCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUpdateInterestCategory", reflect.TypeOf((*MockAdapterInterface)(nil).AdminUpdateInterestCategory), arg0)
+}
+
+// ApplySubscriptionEvent mocks base method.
+func (m *MockAdapterInterface) ApplySubscriptionEvent(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySubscriptionEvent", event, sub)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplySubscriptionEvent indicates an expected call of ApplySubscriptionEvent.
+func (mr *MockAdapterInterfaceMockRecorder) ApplySubscriptionEvent(event, sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySubscriptionEvent", reflect.TypeOf((*MockAdapterInterface)(nil).ApplySubscriptionEvent), event, sub)
+}
+
+// CancelSubscription mocks base method.
+func (m *MockAdapterInterface) CancelSubscription(userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSubscription", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSubscription indicates an expected call of CancelSubscription.
+func (mr *MockAdapterInterfaceMockRecorder) CancelSubscription(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSubscription", reflect.TypeOf((*MockAdapterInterface)(nil).CancelSubscription), userId)
+}
+
+// ConfirmAccountChange mocks base method.
+func (m *MockAdapterInterface) ConfirmAccountChange(change entities.PendingAccountChange, maxAttempts int, audit entities.AccountAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmAccountChange", change, maxAttempts, audit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmAccountChange indicates an expected call of ConfirmAccountChange.
+func (mr *MockAdapterInterfaceMockRecorder) ConfirmAccountChange(change, maxAttempts, audit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmAccountChange", reflect.TypeOf((*MockAdapterInterface)(nil).ConfirmAccountChange), change, maxAttempts, audit)
+}
+
+// CountUserInterests mocks base method.
+func (m *MockAdapterInterface) CountUserInterests(profileId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserInterests", profileId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserInterests indicates an expected call of CountUserInterests.
+func (mr *MockAdapterInterfaceMockRecorder) CountUserInterests(profileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserInterests", reflect.TypeOf((*MockAdapterInterface)(nil).CountUserInterests), profileId)
+}
+
 // CreateProfile mocks base method.
 func (m *MockAdapterInterface) CreateProfile(userID string) error {
 	m.ctrl.T.Helper()
@@ -149,6 +321,80 @@ func (mr *MockAdapterInterfaceMockRecorder) DecrementLikeCount(userId interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementLikeCount", reflect.TypeOf((*MockAdapterInterface)(nil).DecrementLikeCount), userId)
 }
 
+// DeleteTranslation mocks base method.
+func (m *MockAdapterInterface) DeleteTranslation(arg0 entities.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockAdapterInterfaceMockRecorder) DeleteTranslation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockAdapterInterface)(nil).DeleteTranslation), arg0)
+}
+
+// ExpireSubscriptions mocks base method.
+func (m *MockAdapterInterface) ExpireSubscriptions() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireSubscriptions")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireSubscriptions indicates an expected call of ExpireSubscriptions.
+func (mr *MockAdapterInterfaceMockRecorder) ExpireSubscriptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireSubscriptions", reflect.TypeOf((*MockAdapterInterface)(nil).ExpireSubscriptions))
+}
+
+// ExportUserData mocks base method.
+func (m *MockAdapterInterface) ExportUserData(userId string) (helperstruct.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", userId)
+	ret0, _ := ret[0].(helperstruct.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockAdapterInterfaceMockRecorder) ExportUserData(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockAdapterInterface)(nil).ExportUserData), userId)
+}
+
+// FetchCandidateCards mocks base method.
+func (m *MockAdapterInterface) FetchCandidateCards(profileIds, locales []string) (map[string]helperstruct.CandidateCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCandidateCards", profileIds, locales)
+	ret0, _ := ret[0].(map[string]helperstruct.CandidateCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCandidateCards indicates an expected call of FetchCandidateCards.
+func (mr *MockAdapterInterfaceMockRecorder) FetchCandidateCards(profileIds, locales interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCandidateCards", reflect.TypeOf((*MockAdapterInterface)(nil).FetchCandidateCards), profileIds, locales)
+}
+
+// FetchCandidates mocks base method.
+func (m *MockAdapterInterface) FetchCandidates(q helperstruct.CandidateQuery) ([]helperstruct.Home, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCandidates", q)
+	ret0, _ := ret[0].([]helperstruct.Home)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCandidates indicates an expected call of FetchCandidates.
+func (mr *MockAdapterInterfaceMockRecorder) FetchCandidates(q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCandidates", reflect.TypeOf((*MockAdapterInterface)(nil).FetchCandidates), q)
+}
+
 // FetchImages mocks base method.
 func (m *MockAdapterInterface) FetchImages(id string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -165,18 +411,18 @@ func (mr *MockAdapterInterfaceMockRecorder) FetchImages(id interface{}) *gomock.
 }
 
 // FetchInterests mocks base method.
-func (m *MockAdapterInterface) FetchInterests(id string) ([]string, error) {
+func (m *MockAdapterInterface) FetchInterests(id string, locales []string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchInterests", id)
+	ret := m.ctrl.Call(m, "FetchInterests", id, locales)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchInterests indicates an expected call of FetchInterests.
-func (mr *MockAdapterInterfaceMockRecorder) FetchInterests(id interface{}) *gomock.Call {
+func (mr *MockAdapterInterfaceMockRecorder) FetchInterests(id, locales interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInterests", reflect.TypeOf((*MockAdapterInterface)(nil).FetchInterests), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInterests", reflect.TypeOf((*MockAdapterInterface)(nil).FetchInterests), id, locales)
 }
 
 // FetchPreference mocks base method.
@@ -209,19 +455,19 @@ func (mr *MockAdapterInterfaceMockRecorder) FetchUser(profile interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUser", reflect.TypeOf((*MockAdapterInterface)(nil).FetchUser), profile)
 }
 
-// FetchUsers mocks base method.
-func (m *MockAdapterInterface) FetchUsers(maxAge, minAge, gender int, id string) ([]helperstruct.Home, error) {
+// FetchUserImages mocks base method.
+func (m *MockAdapterInterface) FetchUserImages(userId string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchUsers", maxAge, minAge, gender, id)
-	ret0, _ := ret[0].([]helperstruct.Home)
+	ret := m.ctrl.Call(m, "FetchUserImages", userId)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchUsers indicates an expected call of FetchUsers.
-func (mr *MockAdapterInterfaceMockRecorder) FetchUsers(maxAge, minAge, gender, id interface{}) *gomock.Call {
+// FetchUserImages indicates an expected call of FetchUserImages.
+func (mr *MockAdapterInterfaceMockRecorder) FetchUserImages(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUsers", reflect.TypeOf((*MockAdapterInterface)(nil).FetchUsers), maxAge, minAge, gender, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserImages", reflect.TypeOf((*MockAdapterInterface)(nil).FetchUserImages), userId)
 }
 
 // GetAddressByProfileId mocks base method.
@@ -269,34 +515,34 @@ func (mr *MockAdapterInterfaceMockRecorder) GetAge(profileId interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAge", reflect.TypeOf((*MockAdapterInterface)(nil).GetAge), profileId)
 }
 
-// GetGenderById mocks base method.
-func (m *MockAdapterInterface) GetGenderById(id int) (helperstruct.GenderHelper, error) {
+// GetEntitlements mocks base method.
+func (m *MockAdapterInterface) GetEntitlements(userId string) (helperstruct.Entitlements, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGenderById", id)
-	ret0, _ := ret[0].(helperstruct.GenderHelper)
+	ret := m.ctrl.Call(m, "GetEntitlements", userId)
+	ret0, _ := ret[0].(helperstruct.Entitlements)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGenderById indicates an expected call of GetGenderById.
-func (mr *MockAdapterInterfaceMockRecorder) GetGenderById(id interface{}) *gomock.Call {
+// GetEntitlements indicates an expected call of GetEntitlements.
+func (mr *MockAdapterInterfaceMockRecorder) GetEntitlements(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenderById", reflect.TypeOf((*MockAdapterInterface)(nil).GetGenderById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntitlements", reflect.TypeOf((*MockAdapterInterface)(nil).GetEntitlements), userId)
 }
 
-// GetGenderByName mocks base method.
-func (m *MockAdapterInterface) GetGenderByName(gender string) (entities.Gender, error) {
+// GetGenderById mocks base method.
+func (m *MockAdapterInterface) GetGenderById(id int) (helperstruct.GenderHelper, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGenderByName", gender)
-	ret0, _ := ret[0].(entities.Gender)
+	ret := m.ctrl.Call(m, "GetGenderById", id)
+	ret0, _ := ret[0].(helperstruct.GenderHelper)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGenderByName indicates an expected call of GetGenderByName.
-func (mr *MockAdapterInterfaceMockRecorder) GetGenderByName(gender interface{}) *gomock.Call {
+// GetGenderById indicates an expected call of GetGenderById.
+func (mr *MockAdapterInterfaceMockRecorder) GetGenderById(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenderByName", reflect.TypeOf((*MockAdapterInterface)(nil).GetGenderByName), gender)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenderById", reflect.TypeOf((*MockAdapterInterface)(nil).GetGenderById), id)
 }
 
 // GetGenderByProfileId mocks base method.
@@ -329,19 +575,49 @@ func (mr *MockAdapterInterfaceMockRecorder) GetInterestById(id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestById", reflect.TypeOf((*MockAdapterInterface)(nil).GetInterestById), id)
 }
 
-// GetInterestByName mocks base method.
-func (m *MockAdapterInterface) GetInterestByName(interest string) (entities.Interests, error) {
+// GetInterestCategoryById mocks base method.
+func (m *MockAdapterInterface) GetInterestCategoryById(id int) (entities.InterestCategory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInterestByName", interest)
-	ret0, _ := ret[0].(entities.Interests)
+	ret := m.ctrl.Call(m, "GetInterestCategoryById", id)
+	ret0, _ := ret[0].(entities.InterestCategory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetInterestByName indicates an expected call of GetInterestByName.
-func (mr *MockAdapterInterfaceMockRecorder) GetInterestByName(interest interface{}) *gomock.Call {
+// GetInterestCategoryById indicates an expected call of GetInterestCategoryById.
+func (mr *MockAdapterInterfaceMockRecorder) GetInterestCategoryById(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestByName", reflect.TypeOf((*MockAdapterInterface)(nil).GetInterestByName), interest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestCategoryById", reflect.TypeOf((*MockAdapterInterface)(nil).GetInterestCategoryById), id)
+}
+
+// GetPendingAccountChanges mocks base method.
+func (m *MockAdapterInterface) GetPendingAccountChanges(userId string) ([]entities.PendingAccountChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingAccountChanges", userId)
+	ret0, _ := ret[0].([]entities.PendingAccountChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingAccountChanges indicates an expected call of GetPendingAccountChanges.
+func (mr *MockAdapterInterfaceMockRecorder) GetPendingAccountChanges(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingAccountChanges", reflect.TypeOf((*MockAdapterInterface)(nil).GetPendingAccountChanges), userId)
+}
+
+// GetPlans mocks base method.
+func (m *MockAdapterInterface) GetPlans() ([]entities.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlans")
+	ret0, _ := ret[0].([]entities.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlans indicates an expected call of GetPlans.
+func (mr *MockAdapterInterfaceMockRecorder) GetPlans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlans", reflect.TypeOf((*MockAdapterInterface)(nil).GetPlans))
 }
 
 // GetPreferenceByProfileId mocks base method.
@@ -359,6 +635,21 @@ func (mr *MockAdapterInterfaceMockRecorder) GetPreferenceByProfileId(profileId i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferenceByProfileId", reflect.TypeOf((*MockAdapterInterface)(nil).GetPreferenceByProfileId), profileId)
 }
 
+// GetProfileDetails mocks base method.
+func (m *MockAdapterInterface) GetProfileDetails(profileId string) (helperstruct.ProfileDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileDetails", profileId)
+	ret0, _ := ret[0].(helperstruct.ProfileDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileDetails indicates an expected call of GetProfileDetails.
+func (mr *MockAdapterInterfaceMockRecorder) GetProfileDetails(profileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileDetails", reflect.TypeOf((*MockAdapterInterface)(nil).GetProfileDetails), profileId)
+}
+
 // GetProfileIdByUserId mocks base method.
 func (m *MockAdapterInterface) GetProfileIdByUserId(userId string) (string, error) {
 	m.ctrl.T.Helper()
@@ -464,6 +755,51 @@ func (mr *MockAdapterInterfaceMockRecorder) GetUserInterestById(profileId, inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInterestById", reflect.TypeOf((*MockAdapterInterface)(nil).GetUserInterestById), profileId, interestId)
 }
 
+// GetUsersToPurge mocks base method.
+func (m *MockAdapterInterface) GetUsersToPurge(deletedBefore time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersToPurge", deletedBefore)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersToPurge indicates an expected call of GetUsersToPurge.
+func (mr *MockAdapterInterfaceMockRecorder) GetUsersToPurge(deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersToPurge", reflect.TypeOf((*MockAdapterInterface)(nil).GetUsersToPurge), deletedBefore)
+}
+
+// HasLiked mocks base method.
+func (m *MockAdapterInterface) HasLiked(userId, likedId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLiked", userId, likedId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLiked indicates an expected call of HasLiked.
+func (mr *MockAdapterInterfaceMockRecorder) HasLiked(userId, likedId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLiked", reflect.TypeOf((*MockAdapterInterface)(nil).HasLiked), userId, likedId)
+}
+
+// ImportLikes mocks base method.
+func (m *MockAdapterInterface) ImportLikes(likes []entities.UserLike) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLikes", likes)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportLikes indicates an expected call of ImportLikes.
+func (mr *MockAdapterInterfaceMockRecorder) ImportLikes(likes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLikes", reflect.TypeOf((*MockAdapterInterface)(nil).ImportLikes), likes)
+}
+
 // IsUserExist mocks base method.
 func (m *MockAdapterInterface) IsUserExist(id string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -479,32 +815,174 @@ func (mr *MockAdapterInterfaceMockRecorder) IsUserExist(id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserExist", reflect.TypeOf((*MockAdapterInterface)(nil).IsUserExist), id)
 }
 
-// UpdateAge mocks base method.
-func (m *MockAdapterInterface) UpdateAge(age int, profileId string) error {
+// PurgeUser mocks base method.
+func (m *MockAdapterInterface) PurgeUser(userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockAdapterInterfaceMockRecorder) PurgeUser(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockAdapterInterface)(nil).PurgeUser), userId)
+}
+
+// RecordLike mocks base method.
+func (m *MockAdapterInterface) RecordLike(userId, likedId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLike", userId, likedId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordLike indicates an expected call of RecordLike.
+func (mr *MockAdapterInterfaceMockRecorder) RecordLike(userId, likedId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLike", reflect.TypeOf((*MockAdapterInterface)(nil).RecordLike), userId, likedId)
+}
+
+// RemoveLike mocks base method.
+func (m *MockAdapterInterface) RemoveLike(userId, likedId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLike", userId, likedId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLike indicates an expected call of RemoveLike.
+func (mr *MockAdapterInterfaceMockRecorder) RemoveLike(userId, likedId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLike", reflect.TypeOf((*MockAdapterInterface)(nil).RemoveLike), userId, likedId)
+}
+
+// ResetLikeQuotas mocks base method.
+func (m *MockAdapterInterface) ResetLikeQuotas() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLikeQuotas")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetLikeQuotas indicates an expected call of ResetLikeQuotas.
+func (mr *MockAdapterInterfaceMockRecorder) ResetLikeQuotas() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLikeQuotas", reflect.TypeOf((*MockAdapterInterface)(nil).ResetLikeQuotas))
+}
+
+// SetDiscoveryMode mocks base method.
+func (m *MockAdapterInterface) SetDiscoveryMode(userId string, mode int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDiscoveryMode", userId, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDiscoveryMode indicates an expected call of SetDiscoveryMode.
+func (mr *MockAdapterInterfaceMockRecorder) SetDiscoveryMode(userId, mode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDiscoveryMode", reflect.TypeOf((*MockAdapterInterface)(nil).SetDiscoveryMode), userId, mode)
+}
+
+// SetUserInterests mocks base method.
+func (m *MockAdapterInterface) SetUserInterests(profileId string, interestIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserInterests", profileId, interestIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserInterests indicates an expected call of SetUserInterests.
+func (mr *MockAdapterInterfaceMockRecorder) SetUserInterests(profileId, interestIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserInterests", reflect.TypeOf((*MockAdapterInterface)(nil).SetUserInterests), profileId, interestIds)
+}
+
+// SoftDeleteUser mocks base method.
+func (m *MockAdapterInterface) SoftDeleteUser(userId string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteUser", userId)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteUser indicates an expected call of SoftDeleteUser.
+func (mr *MockAdapterInterfaceMockRecorder) SoftDeleteUser(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteUser", reflect.TypeOf((*MockAdapterInterface)(nil).SoftDeleteUser), userId)
+}
+
+// StartSubscription mocks base method.
+func (m *MockAdapterInterface) StartSubscription(sub entities.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSubscription", sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartSubscription indicates an expected call of StartSubscription.
+func (mr *MockAdapterInterfaceMockRecorder) StartSubscription(sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSubscription", reflect.TypeOf((*MockAdapterInterface)(nil).StartSubscription), sub)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockAdapterInterface) UpdateAccount(userId string, name *string, pending []entities.PendingAccountChange, audits []entities.AccountAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", userId, name, pending, audits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockAdapterInterfaceMockRecorder) UpdateAccount(userId, name, pending, audits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAdapterInterface)(nil).UpdateAccount), userId, name, pending, audits)
+}
+
+// UpdateDateOfBirth mocks base method.
+func (m *MockAdapterInterface) UpdateDateOfBirth(dob time.Time, profileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDateOfBirth", dob, profileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDateOfBirth indicates an expected call of UpdateDateOfBirth.
+func (mr *MockAdapterInterfaceMockRecorder) UpdateDateOfBirth(dob, profileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDateOfBirth", reflect.TypeOf((*MockAdapterInterface)(nil).UpdateDateOfBirth), dob, profileId)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockAdapterInterface) UpdateLocation(location helperstruct.Location, profileId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAge", age, profileId)
+	ret := m.ctrl.Call(m, "UpdateLocation", location, profileId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateAge indicates an expected call of UpdateAge.
-func (mr *MockAdapterInterfaceMockRecorder) UpdateAge(age, profileId interface{}) *gomock.Call {
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockAdapterInterfaceMockRecorder) UpdateLocation(location, profileId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAge", reflect.TypeOf((*MockAdapterInterface)(nil).UpdateAge), age, profileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockAdapterInterface)(nil).UpdateLocation), location, profileId)
 }
 
-// UpdateSubscription mocks base method.
-func (m *MockAdapterInterface) UpdateSubscription(userId string, subscribed bool) error {
+// UpdateProfileDetails mocks base method.
+func (m *MockAdapterInterface) UpdateProfileDetails(profileId string, update helperstruct.ProfileUpdate) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSubscription", userId, subscribed)
+	ret := m.ctrl.Call(m, "UpdateProfileDetails", profileId, update)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateSubscription indicates an expected call of UpdateSubscription.
-func (mr *MockAdapterInterfaceMockRecorder) UpdateSubscription(userId, subscribed interface{}) *gomock.Call {
+// UpdateProfileDetails indicates an expected call of UpdateProfileDetails.
+func (mr *MockAdapterInterfaceMockRecorder) UpdateProfileDetails(profileId, update interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockAdapterInterface)(nil).UpdateSubscription), userId, subscribed)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfileDetails", reflect.TypeOf((*MockAdapterInterface)(nil).UpdateProfileDetails), profileId, update)
 }
 
 // UploadProfileImage mocks base method.
@@ -522,6 +1000,20 @@ func (mr *MockAdapterInterfaceMockRecorder) UploadProfileImage(Image, ProfileId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadProfileImage", reflect.TypeOf((*MockAdapterInterface)(nil).UploadProfileImage), Image, ProfileId)
 }
 
+// UpsertTranslation mocks base method.
+func (m *MockAdapterInterface) UpsertTranslation(arg0 entities.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTranslation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertTranslation indicates an expected call of UpsertTranslation.
+func (mr *MockAdapterInterfaceMockRecorder) UpsertTranslation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTranslation", reflect.TypeOf((*MockAdapterInterface)(nil).UpsertTranslation), arg0)
+}
+
 // UserAddAddress mocks base method.
 func (m *MockAdapterInterface) UserAddAddress(arg0 entities.Address) error {
 	m.ctrl.T.Helper()
@@ -564,6 +1056,20 @@ func (mr *MockAdapterInterfaceMockRecorder) UserAddInterest(interest interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserAddInterest", reflect.TypeOf((*MockAdapterInterface)(nil).UserAddInterest), interest)
 }
 
+// UserAddInterestWithin mocks base method.
+func (m *MockAdapterInterface) UserAddInterestWithin(interest entities.UserInterests, maxInterests int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserAddInterestWithin", interest, maxInterests)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserAddInterestWithin indicates an expected call of UserAddInterestWithin.
+func (mr *MockAdapterInterfaceMockRecorder) UserAddInterestWithin(interest, maxInterests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserAddInterestWithin", reflect.TypeOf((*MockAdapterInterface)(nil).UserAddInterestWithin), interest, maxInterests)
+}
+
 // UserAddPreference mocks base method.
 func (m *MockAdapterInterface) UserAddPreference(arg0 entities.Preference) error {
 	m.ctrl.T.Helper()
@@ -621,10 +1127,10 @@ func (mr *MockAdapterInterfaceMockRecorder) UserEditPreference(arg0 interface{})
 }
 
 // UserGetAllGender mocks base method.
-func (m *MockAdapterInterface) UserGetAllGender(profileId string) (helperstruct.GenderHelper, error) {
+func (m *MockAdapterInterface) UserGetAllGender(profileId string) ([]helperstruct.GenderHelper, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UserGetAllGender", profileId)
-	ret0, _ := ret[0].(helperstruct.GenderHelper)
+	ret0, _ := ret[0].([]helperstruct.GenderHelper)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -636,18 +1142,32 @@ func (mr *MockAdapterInterfaceMockRecorder) UserGetAllGender(profileId interface
 }
 
 // UserGetAllInterest mocks base method.
-func (m *MockAdapterInterface) UserGetAllInterest(profileId string) ([]entities.Interests, error) {
+func (m *MockAdapterInterface) UserGetAllInterest(profileId string, locales []string) ([]entities.Interests, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserGetAllInterest", profileId)
+	ret := m.ctrl.Call(m, "UserGetAllInterest", profileId, locales)
 	ret0, _ := ret[0].([]entities.Interests)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UserGetAllInterest indicates an expected call of UserGetAllInterest.
-func (mr *MockAdapterInterfaceMockRecorder) UserGetAllInterest(profileId interface{}) *gomock.Call {
+func (mr *MockAdapterInterfaceMockRecorder) UserGetAllInterest(profileId, locales interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetAllInterest", reflect.TypeOf((*MockAdapterInterface)(nil).UserGetAllInterest), profileId, locales)
+}
+
+// UserRemoveGender mocks base method.
+func (m *MockAdapterInterface) UserRemoveGender(gender entities.UserGenders) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRemoveGender", gender)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserRemoveGender indicates an expected call of UserRemoveGender.
+func (mr *MockAdapterInterfaceMockRecorder) UserRemoveGender(gender interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetAllInterest", reflect.TypeOf((*MockAdapterInterface)(nil).UserGetAllInterest), profileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRemoveGender", reflect.TypeOf((*MockAdapterInterface)(nil).UserRemoveGender), gender)
 }
 
 // UserSignup mocks base method.
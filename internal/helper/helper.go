@@ -1,11 +1,24 @@
 package helper
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/logging"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func HashPassword(password string) (string, error) {
@@ -16,13 +29,66 @@ func HashPassword(password string) (string, error) {
 	return string(hash), nil
 }
 
+// OneTimeCode returns a random six digit code.
+func OneTimeCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// HashCode returns the hex encoded SHA-256 digest of a one-time code, which
+// is stored in place of the code.
+func HashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
 func CompareHashedPassword(hashedPass, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPass), []byte(password))
+	return err == nil
+}
+
+// NormalizeEmail trims an email and lowercases it, so the same address is
+// stored and looked up the same way however it was typed.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone removes the spaces, dashes, dots and brackets people type
+// in phone numbers and turns a leading 00 into +, so "+91 98765-43210" and
+// "0091 9876543210" both become the E.164 "+919876543210". Numbers without
+// a country code are returned without formatting and fail validation.
+func NormalizePhone(phone string) string {
+	phone = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, phone)
+	if strings.HasPrefix(phone, "00") {
+		phone = "+" + phone[2:]
+	}
+	return phone
+}
+
+// DOBLayout is the preferred date of birth format. ParseDOB also accepts
+// RFC 3339 timestamps, which older clients send.
+const DOBLayout = time.DateOnly
+
+// ParseDOB parses a date of birth and drops any time of day, so the stored
+// date is the calendar date the client sent.
+func ParseDOB(dob string) (time.Time, error) {
+	date, err := time.Parse(DOBLayout, dob)
 	if err != nil {
-		fmt.Println(err.Error())
-		return false
+		var rfcErr error
+		if date, rfcErr = time.Parse(time.RFC3339Nano, dob); rfcErr != nil {
+			return time.Time{}, err
+		}
 	}
-	return true
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
 }
 
 func CalculateAge(dob time.Time) int {
@@ -56,7 +122,6 @@ func Abs(i int) int {
 	return -i
 }
 
-
 func QuickSort(a []float64, b []helperstruct.Home, start, end int) {
 	if start < end {
 		p := partition(a, b, start, end)
@@ -78,3 +143,158 @@ func partition(a []float64, b []helperstruct.Home, start, end int) int {
 	b[i+1], b[end] = b[end], b[i+1]
 	return i + 1
 }
+
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two points given in
+// decimal degrees, using the haversine formula.
+func DistanceKm(from, to helperstruct.Location) float64 {
+	lat1, lat2 := from.Latitude*math.Pi/180, to.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (to.Longitude - from.Longitude) * math.Pi / 180
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the smallest latitude and longitude ranges holding
+// every point within km of center. The longitude range is the whole circle
+// when the box reaches a pole or crosses the antimeridian.
+func BoundingBox(center helperstruct.Location, km float64) (minLat, maxLat, minLon, maxLon float64) {
+	dLat := km / earthRadiusKm * 180 / math.Pi
+	minLat, maxLat = center.Latitude-dLat, center.Latitude+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		return math.Max(minLat, -90), math.Min(maxLat, 90), -180, 180
+	}
+	dLon := math.Asin(math.Sin(km/earthRadiusKm)/math.Cos(center.Latitude*math.Pi/180)) * 180 / math.Pi
+	minLon, maxLon = center.Longitude-dLon, center.Longitude+dLon
+	if minLon < -180 || maxLon > 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLon, maxLon
+}
+
+// RoundDistanceKm rounds a distance for display. Distances under a
+// kilometre are shown as one so cards never reveal how close someone is.
+func RoundDistanceKm(km float64) int32 {
+	return int32(math.Max(1, math.Round(km)))
+}
+
+// AcceptLanguageHeader is the metadata key clients list their preferred
+// locales in, formatted like the HTTP Accept-Language header.
+const AcceptLanguageHeader = "accept-language"
+
+// maxLocales bounds how many locales of a request are looked up.
+const maxLocales = 8
+
+// NormalizeLocale lowercases a language tag and separates its parts with
+// hyphens, so pt_BR and pt-br are the same locale.
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language value from
+// most to least preferred. A regional locale is followed by its language
+// unless a later one has the same language, so "pt-BR, en" looks up pt-br,
+// pt and en.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		locale := NormalizeLocale(params[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{locale, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	var locales []string
+	seen := make(map[string]bool)
+	add := func(locale string) {
+		if !seen[locale] && len(locales) < maxLocales {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	for i, tag := range tags {
+		add(tag.locale)
+		base, _, regional := strings.Cut(tag.locale, "-")
+		if !regional {
+			continue
+		}
+		later := false
+		for _, next := range tags[i+1:] {
+			if next.locale == base || strings.HasPrefix(next.locale, base+"-") {
+				later = true
+				break
+			}
+		}
+		if !later {
+			add(base)
+		}
+	}
+	return locales
+}
+
+// Locales returns the locales the caller asked for in the
+// AcceptLanguageHeader metadata, most preferred first.
+func Locales(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	return ParseAcceptLanguage(strings.Join(md.Get(AcceptLanguageHeader), ","))
+}
+
+// MaskPaths returns the fields of msg an update with mask changes: the paths
+// of the mask, or every field when the mask is empty.
+func MaskPaths(mask *fieldmaskpb.FieldMask, msg proto.Message) []string {
+	if len(mask.GetPaths()) > 0 {
+		return mask.GetPaths()
+	}
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	paths := make([]string, fields.Len())
+	for i := range paths {
+		paths[i] = string(fields.Get(i).Name())
+	}
+	return paths
+}
+
+// Redact hides most of an account value for audits. Emails and phones are
+// masked as the logs mask them; anything else keeps its first letter. Empty
+// values stay empty.
+func Redact(field, value string) string {
+	if value == "" {
+		return ""
+	}
+	switch field {
+	case "email":
+		return logging.MaskEmail(value)
+	case "phone":
+		return logging.MaskPhone(value)
+	}
+	return firstRune(value) + "***"
+}
+
+func firstRune(s string) string {
+	for _, r := range s {
+		return string(r)
+	}
+	return ""
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log/slog"
-	"os"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/akshaybt001/DatingApp_UserService/entities"
@@ -12,74 +16,129 @@ import (
 	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
 	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
 	"github.com/akshaybt001/DatingApp_UserService/internal/usecases"
+	"github.com/akshaybt001/DatingApp_UserService/logging"
+	"github.com/akshaybt001/DatingApp_UserService/metrics"
+	"github.com/akshaybt001/DatingApp_UserService/tracing"
 	"github.com/akshaybt001/DatingApp_proto_files/pb"
 	"github.com/go-redis/redis"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
+// DefaultMaxInterests is how many interests a user can have unless
+// SetMaxInterests says otherwise.
+const DefaultMaxInterests = 10
+
+// DefaultAccountPurgeGrace is how long a deleted account is kept unless
+// SetAccountPurgeGrace says otherwise.
+const DefaultAccountPurgeGrace = 30 * 24 * time.Hour
+
+// DefaultSeenRecycleAfter is how long a passed profile stays out of a
+// user's recommendations unless SetSeenRecycling says otherwise.
+const DefaultSeenRecycleAfter = 30 * 24 * time.Hour
+
+// DefaultSubscriptionPeriod is how long a subscription lasts when
+// UpdateSubscription is not given an expiry.
+const DefaultSubscriptionPeriod = 30 * 24 * time.Hour
+
 type UserService struct {
 	adapters adapters.AdapterInterface
 	usecases usecases.Usecases
+	redis    *redis.Client
 	pb.UnimplementedUserServiceServer
+
+	maxInterests int
+	purgeGrace   time.Duration
+	seenRecycle  time.Duration
+	likedRecycle time.Duration
+	resetSeen    bool
 }
 
-func NewUserService(adapters adapters.AdapterInterface, usecases usecases.Usecases) *UserService {
+func NewUserService(adapters adapters.AdapterInterface, usecases usecases.Usecases, redis *redis.Client) *UserService {
 	return &UserService{
 		adapters: adapters,
 		usecases: usecases,
+		redis:    redis,
+
+		maxInterests: DefaultMaxInterests,
+		purgeGrace:   DefaultAccountPurgeGrace,
+		seenRecycle:  DefaultSeenRecycleAfter,
 	}
 }
 
-var logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+// SetMaxInterests changes how many interests a user can have.
+func (user *UserService) SetMaxInterests(n int) {
+	user.maxInterests = n
+}
+
+// SetAccountPurgeGrace changes how long a deleted account is kept before
+// PurgeDeletedAccounts removes it.
+func (user *UserService) SetAccountPurgeGrace(d time.Duration) {
+	user.purgeGrace = d
+}
 
-var redisClient *redis.Client
+// SetSeenRecycling changes how long profiles a user passed on and profiles
+// they liked stay out of their recommendations. A liked of zero keeps liked
+// profiles out for good.
+func (user *UserService) SetSeenRecycling(passed, liked time.Duration) {
+	user.seenRecycle = passed
+	user.likedRecycle = liked
+}
 
-func init() {
-	redisClient = redis.NewClient(&redis.Options{
-		Addr:     "redis-service:6379",
-		Password: "",
-		DB:       0,
-	})
+// SetResetSeenOnPreferenceChange decides whether the profiles a user passed
+// on are recommended again once they change their preference.
+func (user *UserService) SetResetSeenOnPreferenceChange(reset bool) {
+	user.resetSeen = reset
+}
+
+// repo returns the adapter bound to ctx, so queries are traced as part of
+// the request and cancelled with it.
+func (user *UserService) repo(ctx context.Context) adapters.AdapterInterface {
+	return adapters.WithContext(user.adapters, ctx)
 }
 
 func (user *UserService) UserSignup(ctx context.Context, req *pb.UserSignupRequest) (*pb.UserSignupResponse, error) {
+	logger := logging.FromContext(ctx)
 	if req.Email == "" {
-		logger.Warn("email can't be empty")
+		logger.WarnContext(ctx, "email can't be empty")
 		return nil, fmt.Errorf("email can't be empty")
 	}
 	if req.Name == "" {
-		logger.Warn("name cant be empty")
+		logger.WarnContext(ctx, "name cant be empty")
 		return nil, fmt.Errorf("name can't be empty")
 	}
 	if req.Password == "" {
-		logger.Warn("password can't be empty")
+		logger.WarnContext(ctx, "password can't be empty")
 		return nil, fmt.Errorf("password can't be empty")
 	}
 	if req.Phone == "" {
-		logger.Warn("phone can't be empty")
+		logger.WarnContext(ctx, "phone can't be empty")
 		return nil, fmt.Errorf("phone can't be empty")
 	}
-	check1, err := user.adapters.GetUserByEmail(req.Email)
+	check1, err := user.repo(ctx).GetUserByEmail(req.Email)
 	if err != nil {
-		logger.Error("error in fetching email", "email", req.Email)
+		logger.ErrorContext(ctx, "error in fetching email", "email", req.Email)
 		return nil, err
 	}
 	if check1.Name != "" {
-		logger.Error("error account already exists with the given email", "email", req.Email)
-		return nil, fmt.Errorf("an account already exists with the given email")
+		logger.ErrorContext(ctx, "error account already exists with the given email", "email", req.Email)
+		return nil, status.Error(codes.AlreadyExists, "an account already exists with the given email")
 	}
-	check2, err := user.adapters.GetUserByPhone(req.Phone)
+	check2, err := user.repo(ctx).GetUserByPhone(req.Phone)
 	if err != nil {
-		logger.Error("error in fetching userby phone")
+		logger.ErrorContext(ctx, "error in fetching userby phone")
 		return nil, err
 	}
 	if check2.Name != "" {
-		logger.Error("error account already exists with the given phone", "phone", req.Phone)
-		return nil, fmt.Errorf("an account already exist with the given phone number")
+		logger.ErrorContext(ctx, "error account already exists with the given phone", "phone", req.Phone)
+		return nil, status.Error(codes.AlreadyExists, "an account already exist with the given phone number")
 	}
 	hashedPassword, err := helper.HashPassword(req.Password)
 	if err != nil {
-		logger.Error("error in hashing password", "email", req.Email)
+		logger.ErrorContext(ctx, "error in hashing password", "email", req.Email)
 		return nil, err
 	}
 	reqEntity := entities.User{
@@ -88,11 +147,17 @@ func (user *UserService) UserSignup(ctx context.Context, req *pb.UserSignupReque
 		Phone:    req.Phone,
 		Password: hashedPassword,
 	}
-	res, err := user.adapters.UserSignup(reqEntity)
+	res, err := user.repo(ctx).UserSignup(reqEntity)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// Another signup took the email or phone after the checks above.
+		logger.WarnContext(ctx, "account already exists", "email", req.Email)
+		return nil, status.Error(codes.AlreadyExists, "an account already exists with the given email or phone number")
+	}
 	if err != nil {
-		logger.Error("error in user signup", "email", req.Email)
+		logger.ErrorContext(ctx, "error in user signup", "email", req.Email)
 		return nil, err
 	}
+	metrics.Signups.Inc()
 	return &pb.UserSignupResponse{
 		Id:    res.ID.String(),
 		Name:  res.Name,
@@ -102,27 +167,38 @@ func (user *UserService) UserSignup(ctx context.Context, req *pb.UserSignupReque
 }
 
 func (user *UserService) UserLogin(ctx context.Context, req *pb.LoginRequest) (*pb.UserSignupResponse, error) {
+	logger := logging.FromContext(ctx)
 	if req.Email == "" {
-		logger.Warn("invalid email", "email-", req.Email)
+		logger.WarnContext(ctx, "invalid email", "email", req.Email)
 		return &pb.UserSignupResponse{}, fmt.Errorf("please enter a valid email")
 	}
-	userData, err := user.adapters.GetUserByEmail(req.Email)
+	userData, err := user.repo(ctx).GetUserByEmail(req.Email)
 	if err != nil {
-		logger.Error("error in fetching userData")
+		logger.ErrorContext(ctx, "error in fetching userData")
+		metrics.Logins.WithLabelValues(metrics.LoginError).Inc()
 		return &pb.UserSignupResponse{}, err
 	}
 	if userData.IsBlocked {
-		logger.Warn("user have been blocked by the admin", "email", req.Email)
+		logger.WarnContext(ctx, "user have been blocked by the admin", "email", req.Email)
+		metrics.Logins.WithLabelValues(metrics.LoginBlocked).Inc()
 		return &pb.UserSignupResponse{}, fmt.Errorf("you have been blocked by the admin")
 	}
 	if userData.Email == "" {
-		logger.Warn("invalid credentials ", "email", req.Email)
+		logger.WarnContext(ctx, "invalid credentials ", "email", req.Email)
+		metrics.Logins.WithLabelValues(metrics.LoginInvalidCredentials).Inc()
 		return &pb.UserSignupResponse{}, fmt.Errorf("invalid credentials")
 	}
 	if !helper.CompareHashedPassword(userData.Password, req.Password) {
-		logger.Error("error in compareing password")
+		logger.ErrorContext(ctx, "error in compareing password")
+		metrics.Logins.WithLabelValues(metrics.LoginInvalidCredentials).Inc()
 		return &pb.UserSignupResponse{}, fmt.Errorf("invalid credentials please try again")
 	}
+	if userData.DeletedAt != nil {
+		logger.WarnContext(ctx, "login to a deleted account", "user_id", userData.ID)
+		metrics.Logins.WithLabelValues(metrics.LoginDeleted).Inc()
+		return &pb.UserSignupResponse{}, fmt.Errorf("this account has been deleted")
+	}
+	metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
 	return &pb.UserSignupResponse{
 		Id:    userData.ID.String(),
 		Name:  userData.Name,
@@ -132,21 +208,22 @@ func (user *UserService) UserLogin(ctx context.Context, req *pb.LoginRequest) (*
 }
 
 func (user *UserService) AdminLogin(ctx context.Context, req *pb.LoginRequest) (*pb.UserSignupResponse, error) {
+	logger := logging.FromContext(ctx)
 	if req.Email == "" {
-		logger.Warn("invalid email", "email", req.Email)
+		logger.WarnContext(ctx, "invalid email", "email", req.Email)
 		return &pb.UserSignupResponse{}, fmt.Errorf("please enter a valid email")
 	}
-	adminData, err := user.adapters.GetAdminByEmail(req.Email)
+	adminData, err := user.repo(ctx).GetAdminByEmail(req.Email)
 	if err != nil {
-		logger.Error("error in fetching admin data")
+		logger.ErrorContext(ctx, "error in fetching admin data")
 		return &pb.UserSignupResponse{}, err
 	}
 	if adminData.Email == "" {
-		logger.Warn("invalid credentials")
+		logger.WarnContext(ctx, "invalid credentials")
 		return &pb.UserSignupResponse{}, fmt.Errorf("invalid credentials")
 	}
 	if !helper.CompareHashedPassword(adminData.Password, req.Password) {
-		logger.Error("error in compareing password")
+		logger.ErrorContext(ctx, "error in compareing password")
 		return &pb.UserSignupResponse{}, fmt.Errorf("invalid credential")
 	}
 	return &pb.UserSignupResponse{
@@ -158,475 +235,1309 @@ func (user *UserService) AdminLogin(ctx context.Context, req *pb.LoginRequest) (
 }
 
 func (user *UserService) CreateProfile(ctx context.Context, req *pb.GetUserById) (*pb.NoArg, error) {
-	if err := user.adapters.CreateProfile(req.Id); err != nil {
+	logger := logging.FromContext(ctx)
+	if err := user.repo(ctx).CreateProfile(req.Id); err != nil {
 		return &pb.NoArg{}, err
 	}
-	logger.Info("creating profile for user", "user_id", req.Id)
+	logger.InfoContext(ctx, "creating profile for user", "user_id", req.Id)
 
 	return &pb.NoArg{}, nil
 }
 
-func (user *UserService) AdminAddInterest(ctx context.Context, req *pb.AddInterestRequest) (*pb.NoArg, error) {
-	reqEntity := entities.Interests{
-		Interest: req.Interest,
-	}
-	check1, err := user.adapters.GetInterestByName(req.Interest)
+// UpdateProfile changes the profile fields named in the update mask and
+// returns the updated profile.
+func (user *UserService) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.ProfileResponse, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.UserId)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
 	if err != nil {
-		logger.Error("error fectching interest by name", "interest_name", req.Interest, "error", err)
-		return nil, err
-	}
-	if check1.Interest != "" {
-		logger.Warn("interest already exist")
-		return nil, fmt.Errorf("interest already exist")
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "error", err)
+		return nil, err
+	}
+	if profile == "" {
+		logger.WarnContext(ctx, "profile not found")
+		return nil, status.Error(codes.NotFound, "profile not found")
+	}
+	details := req.GetProfile()
+	update := helperstruct.ProfileUpdate{Columns: make(map[string]interface{})}
+	paths := helper.MaskPaths(req.UpdateMask, &pb.ProfileDetails{})
+	for _, path := range paths {
+		switch path {
+		case "name":
+			name := details.GetName()
+			update.Name = &name
+		case "bio":
+			update.Columns["bio"] = details.GetBio()
+		case "occupation":
+			update.Columns["occupation"] = details.GetOccupation()
+		case "education":
+			update.Columns["education"] = details.GetEducation()
+		case "heightCm":
+			if height := details.GetHeightCm(); height > 0 {
+				update.Columns["height_cm"] = int(height)
+			} else {
+				update.Columns["height_cm"] = nil
+			}
+		case "drinking":
+			update.Columns["drinking"] = int(details.GetDrinking())
+		case "smoking":
+			update.Columns["smoking"] = int(details.GetSmoking())
+		case "exercise":
+			update.Columns["exercise"] = int(details.GetExercise())
+		case "prompts":
+			prompts := make([]helperstruct.Prompt, len(details.GetPrompts()))
+			for i, prompt := range details.GetPrompts() {
+				prompts[i] = helperstruct.Prompt{Question: prompt.Question, Answer: prompt.Answer}
+			}
+			update.Prompts = &prompts
+		}
 	}
-	err = user.adapters.AdminAddInterest(reqEntity)
-	if err != nil {
+	if err := user.repo(ctx).UpdateProfileDetails(profile, update); err != nil {
+		logger.ErrorContext(ctx, "error updating profile", "error", err)
 		return nil, err
 	}
-	return nil, nil
+	logger.InfoContext(ctx, "profile updated", "fields", paths)
+	return user.profileResponse(ctx, req.UserId, profile)
 }
 
-func (user *UserService) AdminUpdateInterest(ctx context.Context, req *pb.InterestResponse) (*pb.NoArg, error) {
-	reqEntity := entities.Interests{
-		Id:       int(req.Id),
-		Interest: req.Interest,
-	}
-	check1, err := user.adapters.GetInterestByName(req.Interest)
+func (user *UserService) GetProfile(ctx context.Context, req *pb.GetUserById) (*pb.ProfileResponse, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.Id)
 	if err != nil {
-		logger.Error("error fetching the interest by name")
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	if check1.Interest != "" {
-		logger.Warn("interest already exist")
-		return nil, fmt.Errorf("interest already exist")
-	}
-	if err := user.adapters.AdminUpdateInterest(reqEntity); err != nil {
-		return nil, err
+	if profile == "" {
+		logger.WarnContext(ctx, "profile not found", "user_id", req.Id)
+		return nil, status.Error(codes.NotFound, "profile not found")
 	}
-	return nil, nil
+	return user.profileResponse(ctx, req.Id, profile)
 }
 
-func (user *UserService) AdminUpdateGender(ctx context.Context, req *pb.GenderResponse) (*pb.NoArg, error) {
-	reqEntity := entities.Gender{
-		Id:   int(req.Id),
-		Name: req.Gender,
-	}
-	check, err := user.adapters.GetGenderByName(req.Gender)
+func (user *UserService) profileResponse(ctx context.Context, userId, profile string) (*pb.ProfileResponse, error) {
+	logger := logging.FromContext(ctx)
+	details, err := user.repo(ctx).GetProfileDetails(profile)
 	if err != nil {
-		logger.Error("error fetching the gender by name")
+		logger.ErrorContext(ctx, "error fetching profile details", "user_id", userId, "error", err)
 		return nil, err
 	}
-	if check.Name != "" {
-		logger.Warn("gender already exist")
-		return nil, fmt.Errorf("gender already exist")
-	}
-	if err := user.adapters.AdminUpdateGender(reqEntity); err != nil {
+	age, err := user.repo(ctx).GetAge(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "error in fetching age", "user_id", userId, "error", err)
 		return nil, err
 	}
-	return nil, nil
+	return &pb.ProfileResponse{
+		UserId:  userId,
+		Age:     int32(age),
+		Profile: profileDetails(details),
+	}, nil
 }
 
-func (user *UserService) GetAllInterest(e *pb.NoArg, srv pb.UserService_GetAllInterestServer) error {
-	interests, err := user.adapters.AdminGetAllInterest()
-	if err != nil {
-		logger.Error("error in fetching get all interest")
-		return err
+func profileDetails(details helperstruct.ProfileDetails) *pb.ProfileDetails {
+	res := &pb.ProfileDetails{
+		Name:       details.Name,
+		Bio:        details.Bio,
+		Occupation: details.Occupation,
+		Education:  details.Education,
+		Drinking:   pb.Frequency(details.Drinking),
+		Smoking:    pb.Frequency(details.Smoking),
+		Exercise:   pb.Frequency(details.Exercise),
 	}
-	for _, interest := range interests {
-		res := &pb.InterestResponse{
-			Id:       int32(interest.Id),
-			Interest: interest.Interest,
-		}
-		err := srv.Send(res)
-		if err != nil {
-			return err
-		}
+	if details.HeightCm != nil {
+		res.HeightCm = int32(*details.HeightCm)
 	}
-	return nil
+	for _, prompt := range details.Prompts {
+		res.Prompts = append(res.Prompts, &pb.ProfilePrompt{Question: prompt.Question, Answer: prompt.Answer})
+	}
+	return res
 }
 
-func (user *UserService) GetAllGender(e *pb.NoArg, srv pb.UserService_GetAllGenderServer) error {
-	genders, err := user.adapters.AdminGetAllGender()
+// pendingChangeTTL is how long a new email or phone waits to be confirmed.
+const pendingChangeTTL = 24 * time.Hour
+
+// maxConfirmAttempts is how many wrong codes a pending change accepts before
+// it has to be requested again.
+const maxConfirmAttempts = 5
+
+// UpdateAccount changes the account fields named in the update mask. A new
+// name applies at once. A new email or phone needs the current password,
+// must not belong to another account, as in UserSignup, and stays pending
+// until ConfirmAccountChange is called with the one-time code returned for
+// it.
+func (user *UserService) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.AccountResponse, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.UserId)
+	account, err := user.repo(ctx).GetUserById(req.UserId)
 	if err != nil {
-		logger.Error("error in fetching get all gender")
-		return err
+		logger.ErrorContext(ctx, "error in fetching user", "error", err)
+		return nil, err
+	}
+	if account.ID == uuid.Nil {
+		logger.WarnContext(ctx, "user not found")
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	details := req.GetAccount()
+	var name *string
+	var pending []entities.PendingAccountChange
+	var audits []entities.AccountAudit
+	for _, path := range helper.MaskPaths(req.UpdateMask, &pb.AccountDetails{}) {
+		switch path {
+		case "name":
+			if details.GetName() != account.Name {
+				newName := details.GetName()
+				name = &newName
+				audits = append(audits, accountAudit(path, entities.AccountChangeApplied, account.Name, newName))
+			}
+		case "email":
+			if details.GetEmail() != account.Email {
+				pending = append(pending, entities.PendingAccountChange{Field: path, Value: details.GetEmail()})
+				audits = append(audits, accountAudit(path, entities.AccountChangeRequested, account.Email, details.GetEmail()))
+			}
+		case "phone":
+			if details.GetPhone() != account.Phone {
+				pending = append(pending, entities.PendingAccountChange{Field: path, Value: details.GetPhone()})
+				audits = append(audits, accountAudit(path, entities.AccountChangeRequested, account.Phone, details.GetPhone()))
+			}
+		}
 	}
-	for _, gender := range genders {
-		res := &pb.GenderResponse{
-			Id:     int32(gender.Id),
-			Gender: gender.Name,
+	if len(pending) > 0 && !helper.CompareHashedPassword(account.Password, req.CurrentPassword) {
+		logger.WarnContext(ctx, "wrong current password for account change")
+		return nil, status.Error(codes.PermissionDenied, "current password is incorrect")
+	}
+	expiresAt := time.Now().Add(pendingChangeTTL)
+	confirmCodes := make(map[string]string, len(pending))
+	for i, change := range pending {
+		if err := user.checkAccountUnused(ctx, change.Field, change.Value); err != nil {
+			return nil, err
 		}
-		err := srv.Send(res)
+		code, err := helper.OneTimeCode()
 		if err != nil {
-			return err
+			logger.ErrorContext(ctx, "error generating confirmation code", "error", err)
+			return nil, err
+		}
+		confirmCodes[change.Field] = code
+		pending[i].ExpiresAt = expiresAt
+		pending[i].CodeHash = helper.HashCode(code)
+	}
+	if name != nil || len(pending) > 0 {
+		err := user.repo(ctx).UpdateAccount(req.UserId, name, pending, audits)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.WarnContext(ctx, "account details already in use")
+			return nil, status.Error(codes.AlreadyExists, "an account already exists with the given details")
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "error updating account", "error", err)
+			return nil, err
 		}
+		logger.InfoContext(ctx, "account updated", "name_changed", name != nil, "pending_changes", len(pending))
 	}
-	return nil
-}
-
-func (user *UserService) AddInterestUser(ctx context.Context, req *pb.DeleteInterestRequest) (*pb.NoArg, error) {
-	check, err := user.adapters.GetInterestById(int(req.InterestId))
+	res, err := user.accountResponse(ctx, req.UserId)
 	if err != nil {
-		logger.Error("error fectching interest by ID", "interest_id", req.InterestId, "error", err)
 		return nil, err
 	}
-	if check.InterestId == 0 {
-		logger.Warn("Invalid interest ID provided", "interest_id", req.InterestId)
+	res.EmailCode = confirmCodes["email"]
+	res.PhoneCode = confirmCodes["phone"]
+	return res, nil
+}
 
-		return nil, fmt.Errorf("please enter a valid interest id")
-	}
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+// ConfirmAccountChange applies a pending email or phone when given the
+// one-time code UpdateAccount issued for it.
+func (user *UserService) ConfirmAccountChange(ctx context.Context, req *pb.ConfirmAccountChangeRequest) (*pb.AccountResponse, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.UserId, "field", req.Field)
+	account, err := user.repo(ctx).GetUserById(req.UserId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		logger.ErrorContext(ctx, "error in fetching user", "error", err)
 		return nil, err
 	}
-	loggerctx := logger.With("user_id", req.UserId)
-	check1, err := user.adapters.GetUserInterestById(profile, int(req.InterestId))
+	if account.ID == uuid.Nil {
+		logger.WarnContext(ctx, "user not found")
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	old := account.Email
+	if req.Field == "phone" {
+		old = account.Phone
+	}
+	change := entities.PendingAccountChange{UserId: account.ID, Field: req.Field, Value: req.Value, CodeHash: helper.HashCode(req.Code)}
+	err = user.repo(ctx).ConfirmAccountChange(change, maxConfirmAttempts, accountAudit(req.Field, entities.AccountChangeConfirmed, old, req.Value))
+	if errors.Is(err, adapters.ErrNoPendingChange) {
+		logger.WarnContext(ctx, "no pending account change to confirm")
+		return nil, status.Error(codes.FailedPrecondition, "no pending change with this value, request the change again")
+	}
+	if errors.Is(err, adapters.ErrWrongCode) {
+		logger.WarnContext(ctx, "wrong confirmation code")
+		return nil, status.Error(codes.PermissionDenied, "the confirmation code is incorrect")
+	}
+	if errors.Is(err, adapters.ErrTooManyAttempts) {
+		logger.WarnContext(ctx, "too many confirmation attempts")
+		return nil, status.Error(codes.ResourceExhausted, "too many wrong codes, request the change again")
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		logger.WarnContext(ctx, "account details already in use")
+		return nil, status.Errorf(codes.AlreadyExists, "an account already exists with the given %s", req.Field)
+	}
 	if err != nil {
-		loggerctx.Error("error fectching user interest", "interest_id", req.InterestId, "error ", err)
+		logger.ErrorContext(ctx, "error confirming account change", "error", err)
 		return nil, err
 	}
-	if check1.InterestId != 0 {
-		loggerctx.Warn("interest already added for user", "interest_id", req.InterestId)
-		return nil, fmt.Errorf("you already have added this interest please add a new one")
+	logger.InfoContext(ctx, "account change confirmed")
+	return user.accountResponse(ctx, req.UserId)
+}
 
+// checkAccountUnused fails with AlreadyExists when another account has value
+// as its email or phone.
+func (user *UserService) checkAccountUnused(ctx context.Context, field, value string) error {
+	logger := logging.FromContext(ctx)
+	var check entities.User
+	var err error
+	if field == "email" {
+		check, err = user.repo(ctx).GetUserByEmail(value)
+	} else {
+		check, err = user.repo(ctx).GetUserByPhone(value)
 	}
-	profileId, err := uuid.Parse(profile)
 	if err != nil {
-		logger.Error("Error parsing profile Id, profile_id", profile, "error", err)
-		return nil, err
-	}
-	reqEntity := entities.UserInterests{
-		ProfileId:  profileId,
-		InterestId: int(req.InterestId),
+		logger.ErrorContext(ctx, "error in fetching user by "+field, "error", err)
+		return err
 	}
-	if err := user.adapters.UserAddInterest(reqEntity); err != nil {
-		loggerctx.Error("Error adding user interest", "interest_id", req.InterestId, "error", err)
-		return nil, err
+	if check.Name != "" {
+		logger.WarnContext(ctx, "account already exists with the given "+field, field, value)
+		return status.Errorf(codes.AlreadyExists, "an account already exists with the given %s", field)
 	}
-	loggerctx.Info("interest added successfully for user", "interest_id", req.InterestId)
-	return nil, nil
+	return nil
 }
 
-func (user *UserService) AddGenderUser(ctx context.Context, req *pb.UpdateGenderRequest) (*pb.NoArg, error) {
-	check, err := user.adapters.GetGenderById(int(req.GenderId))
+func (user *UserService) accountResponse(ctx context.Context, userId string) (*pb.AccountResponse, error) {
+	logger := logging.FromContext(ctx)
+	account, err := user.repo(ctx).GetUserById(userId)
 	if err != nil {
-		logger.Error("Error to fectching gender id", "gender_id", req.GenderId, "error", err)
+		logger.ErrorContext(ctx, "error in fetching user", "user_id", userId, "error", err)
 		return nil, err
 	}
-	if check.GenderId == 0 {
-		logger.Error("Error gender_id is not correct ")
-		return nil, fmt.Errorf("please enter a valid gender id")
+	pending, err := user.repo(ctx).GetPendingAccountChanges(userId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching pending account changes", "user_id", userId, "error", err)
+		return nil, err
+	}
+	res := &pb.AccountResponse{
+		UserId: userId,
+		Account: &pb.AccountDetails{
+			Name:  account.Name,
+			Email: account.Email,
+			Phone: account.Phone,
+		},
+	}
+	for _, change := range pending {
+		switch change.Field {
+		case "email":
+			res.PendingEmail = change.Value
+		case "phone":
+			res.PendingPhone = change.Value
+		}
+	}
+	return res, nil
+}
+
+func accountAudit(field, event, oldValue, newValue string) entities.AccountAudit {
+	return entities.AccountAudit{
+		Field:    field,
+		Event:    event,
+		OldValue: helper.Redact(field, oldValue),
+		NewValue: helper.Redact(field, newValue),
 	}
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+}
+
+// RequestAccountDeletion deletes an account at once: it is hidden from
+// discovery and can no longer log in. Its data is removed by
+// PurgeDeletedAccounts once the purge grace has passed.
+func (user *UserService) RequestAccountDeletion(ctx context.Context, req *pb.AccountDeletionRequest) (*pb.AccountDeletionResponse, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.UserId)
+	account, err := user.repo(ctx).GetUserById(req.UserId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		logger.ErrorContext(ctx, "error in fetching user", "error", err)
 		return nil, err
 	}
-	loggerctx := logger.With("user_id", req.UserId)
-	check1, err := user.adapters.GetUserGenderById(profile, int(req.GenderId))
+	if account.ID == uuid.Nil {
+		logger.WarnContext(ctx, "user not found")
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if !helper.CompareHashedPassword(account.Password, req.CurrentPassword) {
+		logger.WarnContext(ctx, "wrong current password for account deletion")
+		return nil, status.Error(codes.PermissionDenied, "current password is incorrect")
+	}
+	deletedAt, err := user.repo(ctx).SoftDeleteUser(req.UserId)
 	if err != nil {
-		logger.Error("error fetching the gender_id", "gender_Id", req.GenderId, "error", err)
+		logger.ErrorContext(ctx, "error deleting account", "error", err)
 		return nil, err
 	}
-	if check1.GenderId != 0 {
-		logger.Error("error gender is already added")
-		return nil, fmt.Errorf("you already have added this gender ")
+	purgeAfter := deletedAt.Add(user.purgeGrace)
+	logger.InfoContext(ctx, "account deleted", "purge_after", purgeAfter)
+	return &pb.AccountDeletionResponse{
+		UserId:     req.UserId,
+		PurgeAfter: purgeAfter.UTC().Format(time.RFC3339),
+	}, nil
+}
 
-	}
-	profileId, err := uuid.Parse(profile)
+// PurgeDeletedAccounts removes the data and images of accounts deleted more
+// than the purge grace ago and returns how many were purged. An account
+// that fails is logged and tried again on the next run.
+func (user *UserService) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	logger := logging.FromContext(ctx)
+	ids, err := user.repo(ctx).GetUsersToPurge(time.Now().Add(-user.purgeGrace))
 	if err != nil {
-		logger.Error("Error parsing profile Id, profile_id", profile, "error", err)
-		return nil, err
+		logger.ErrorContext(ctx, "error fetching accounts to purge", "error", err)
+		return 0, err
 	}
-	reqEntity := entities.UserGenders{
-		ProfileId: profileId,
-		GenderId:  int(req.GenderId),
+	var purged []string
+	for _, id := range ids {
+		if err := user.purgeAccount(ctx, id); err != nil {
+			logger.ErrorContext(ctx, "error purging account", "user_id", id, "error", err)
+			continue
+		}
+		purged = append(purged, id)
 	}
-	if err := user.adapters.UserAddGender(reqEntity); err != nil {
-		loggerctx.Error("Error adding user gender", "gender_id", req.GenderId, "error", err)
+	if user.redis != nil && len(purged) > 0 {
+		if err := user.forgetUsers(ctx, purged); err != nil {
+			logger.WarnContext(ctx, "error removing purged accounts from seen users", "error", err)
+		}
+	}
+	return len(purged), nil
+}
 
-		return nil, err
+// forgetUsers removes userIds from the seen users and swipe histories of
+// the profiles they were shown to, so a purged account cannot be rewound
+// to. The profiles are found through the seenByKey index, without scanning
+// the keyspace.
+func (user *UserService) forgetUsers(ctx context.Context, userIds []string) error {
+	ctx, span := tracing.Start(ctx, "redis.SMembers", attribute.Int("redis.keys", len(userIds)))
+	rdb := user.redis.WithContext(ctx)
+	shownTo := make([]*redis.StringSliceCmd, len(userIds))
+	_, err := rdb.Pipelined(func(pipe redis.Pipeliner) error {
+		for i, id := range userIds {
+			shownTo[i] = pipe.SMembers(seenByKey(id))
+		}
+		return nil
+	})
+	if err == nil {
+		_, err = rdb.Pipelined(func(pipe redis.Pipeliner) error {
+			for i, id := range userIds {
+				for _, profile := range shownTo[i].Val() {
+					pipe.ZRem(seenUsersKey(profile), id)
+					pipe.LRem(swipeHistoryKey(profile), 0, id)
+				}
+				pipe.Del(seenByKey(id))
+			}
+			return nil
+		})
 	}
-	loggerctx.Info("gender added successfully for user", "gender_id", req.GenderId)
-	return nil, nil
+	tracing.End(span, err)
+	return err
 }
 
-func (user *UserService) DeleteInterestUser(ctx context.Context, req *pb.DeleteInterestRequest) (*pb.NoArg, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+// purgeAccount removes the images of a user before their rows, so a failed
+// purge still has the rows pointing at the images when it is retried.
+func (user *UserService) purgeAccount(ctx context.Context, userId string) error {
+	images, err := user.repo(ctx).FetchUserImages(userId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
-		return nil, err
+		return err
 	}
-	profileId, err := uuid.Parse(profile)
+	if err := user.usecases.DeleteImages(ctx, images); err != nil {
+		return err
+	}
+	// The redis keys are named after the profile, which is gone once the
+	// user is purged.
+	profile, err := user.repo(ctx).GetProfileIdByUserId(userId)
 	if err != nil {
-		logger.Error("Error parsing profile Id, profile_id", profile, "error", err)
-		return nil, err
+		return err
 	}
-	loggerctx := logger.With("user_id", req.UserId)
-	reqEntity := entities.UserInterests{
-		ProfileId:  profileId,
-		InterestId: int(req.InterestId),
+	if err := user.repo(ctx).PurgeUser(userId); err != nil {
+		return err
 	}
-	if err := user.adapters.UserDeleteInterest(reqEntity); err != nil {
-		loggerctx.Error("Error deleting user interest", "interest_id", req.InterestId, "error", err)
-		return nil, err
+	if user.redis != nil && profile != "" {
+		seenKey := seenUsersKey(profile)
+		redisCtx, span := tracing.Start(ctx, "redis.Del", attribute.String("redis.key", seenKey))
+		err := user.redis.WithContext(redisCtx).Del(seenKey, swipeHistoryKey(profile)).Err()
+		tracing.End(span, err)
+		if err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "error removing displayed users of purged account", "user_id", userId, "error", err)
+		}
 	}
-	loggerctx.Info("gender added successfully for user", "interest_id", req.InterestId)
-	return nil, nil
+	return nil
 }
 
-func (user *UserService) GetAllInterestsUser(req *pb.GetUserById, srv pb.UserService_GetAllInterestsUserServer) error {
-	profileId, err := user.adapters.GetProfileIdByUserId(req.Id)
+// exportChunkSize is the most archive bytes ExportMyData sends per message.
+const exportChunkSize = 64 << 10
+
+// ExportMyData streams a JSON archive of everything stored about a user.
+func (user *UserService) ExportMyData(req *pb.GetUserById, srv pb.UserService_ExportMyDataServer) error {
+	ctx := srv.Context()
+	logger := logging.FromContext(ctx).With("user_id", req.Id)
+	data, err := user.repo(ctx).ExportUserData(req.Id)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error exporting user data", "error", err)
 		return err
 	}
-	interests, err := user.adapters.UserGetAllInterest(profileId)
+	if len(data["account"]) == 0 {
+		logger.WarnContext(ctx, "user not found")
+		return status.Error(codes.NotFound, "user not found")
+	}
+	archive, err := json.Marshal(struct {
+		UserId     string                  `json:"user_id"`
+		ExportedAt time.Time               `json:"exported_at"`
+		Data       helperstruct.DataExport `json:"data"`
+	}{req.Id, time.Now().UTC(), data})
 	if err != nil {
-		logger.Error("Error in fetching interests")
+		logger.ErrorContext(ctx, "error encoding user data", "error", err)
 		return err
 	}
-	for _, interest := range interests {
-		res := &pb.InterestResponse{
-			Id:       int32(interest.Id),
-			Interest: interest.Interest,
-		}
-
-		if err := srv.Send(res); err != nil {
+	for len(archive) > 0 {
+		n := min(len(archive), exportChunkSize)
+		if err := srv.Send(&pb.DataExportChunk{Data: archive[:n]}); err != nil {
+			logger.ErrorContext(ctx, "error sending user data", "error", err)
 			return err
 		}
+		archive = archive[n:]
 	}
-	logger.Info("fetching interest is successful")
+	logger.InfoContext(ctx, "user data exported")
 	return nil
 }
 
-func (user *UserService) UserAddAddress(ctx context.Context, req *pb.AddAddressRequest) (*pb.NoArg, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+// SetDiscoveryMode changes who a user is recommended to. Users hidden by
+// their mode are not added to anyone's displayed users, so they can be
+// recommended again once they are visible.
+func (user *UserService) SetDiscoveryMode(ctx context.Context, req *pb.DiscoveryModeRequest) (*pb.DiscoveryModeResponse, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.UserId)
+	account, err := user.repo(ctx).GetUserById(req.UserId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		logger.ErrorContext(ctx, "error in fetching user", "error", err)
 		return nil, err
 	}
-	profileId, err := uuid.Parse(profile)
-	if err != nil {
-		logger.Error("Error parsing profile Id, profile_id", profile, "error", err)
+	if account.ID == uuid.Nil {
+		logger.WarnContext(ctx, "user not found")
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err := user.repo(ctx).SetDiscoveryMode(req.UserId, int(req.Mode)); err != nil {
+		logger.ErrorContext(ctx, "error setting discovery mode", "error", err)
 		return nil, err
 	}
-	address, err := user.adapters.GetAddressByProfileId(profile)
+	logger.InfoContext(ctx, "discovery mode changed", "mode", req.Mode.String())
+	return &pb.DiscoveryModeResponse{UserId: req.UserId, Mode: req.Mode}, nil
+}
+
+func (user *UserService) GetDiscoveryMode(ctx context.Context, req *pb.GetUserById) (*pb.DiscoveryModeResponse, error) {
+	logger := logging.FromContext(ctx)
+	account, err := user.repo(ctx).GetUserById(req.Id)
 	if err != nil {
-		logger.Error("Error fetching address", "profile_id", profile, "error", err)
+		logger.ErrorContext(ctx, "error in fetching user", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	if address.Country != "" {
-		logger.Error("address is already exists")
-		return nil, fmt.Errorf("you have already added an address please edit the existing")
-	}
-	reqEntity := entities.Address{
-		Country:   req.Country,
-		State:     req.State,
-		District:  req.District,
-		City:      req.City,
-		ProfileId: profileId,
-	}
-	if err := user.adapters.UserAddAddress(reqEntity); err != nil {
-		logger.Error("Error adding user address", "error", err)
-		return nil, err
+	if account.ID == uuid.Nil {
+		logger.WarnContext(ctx, "user not found", "user_id", req.Id)
+		return nil, status.Error(codes.NotFound, "user not found")
 	}
-	logger.Info("address added successfully for user")
-	return nil, nil
+	return &pb.DiscoveryModeResponse{UserId: req.Id, Mode: pb.DiscoveryMode(account.DiscoveryMode)}, nil
 }
 
-func (user *UserService) UserEditAddress(ctx context.Context, req *pb.AddressResponse) (*pb.NoArg, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+func (user *UserService) AdminAddInterest(ctx context.Context, req *pb.AddInterestRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	category, err := user.interestCategory(ctx, req.CategoryId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
 		return nil, err
 	}
-	profileId, err := uuid.Parse(profile)
-	if err != nil {
-		logger.Error("Error parsing profile Id, profile_id", profile, "error", err)
-		return nil, err
+	reqEntity := entities.Interests{
+		Interest:   req.Interest,
+		CategoryId: category,
 	}
-	reqEntity := entities.Address{
-		Country:   req.Country,
-		State:     req.State,
-		District:  req.District,
-		City:      req.City,
-		ProfileId: profileId,
+	err = user.repo(ctx).AdminAddInterest(reqEntity)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		logger.WarnContext(ctx, "interest already exist", "interest_name", req.Interest)
+		return nil, status.Error(codes.AlreadyExists, "interest already exist")
 	}
-	if err := user.adapters.UserEditAddress(reqEntity); err != nil {
-		logger.Error("Error editing user address", "error", err)
+	if err != nil {
+		logger.ErrorContext(ctx, "error in add interest by admin", "interest_name", req.Interest, "error", err)
 		return nil, err
 	}
-	logger.Info("address edited successfully for user", "user_id", req.UserId)
 	return nil, nil
 }
 
-func (user *UserService) UserEditPreference(ctx context.Context, req *pb.PreferenceResponse) (*pb.NoArg, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+func (user *UserService) AdminUpdateInterest(ctx context.Context, req *pb.InterestResponse) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	category, err := user.interestCategory(ctx, req.CategoryId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
 		return nil, err
 	}
-	profileId, err := uuid.Parse(profile)
-	if err != nil {
-		logger.Error("Error parsing profile Id, profile_id", profile, "error", err)
-		return nil, err
+	reqEntity := entities.Interests{
+		Id:         int(req.Id),
+		Interest:   req.Interest,
+		CategoryId: category,
 	}
-	reqEntity := entities.Preference{
-		MinAge:     int(req.Minage),
-		MaxAge:     int(req.Maxage),
-		GenderId:   int(req.Gender),
-		DesireCity: req.Desirecity,
-		ProfileId:  profileId,
+	err = user.repo(ctx).AdminUpdateInterest(reqEntity)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		logger.WarnContext(ctx, "interest already exist", "interest_name", req.Interest)
+		return nil, status.Error(codes.AlreadyExists, "interest already exist")
 	}
-	if err := user.adapters.UserEditPreference(reqEntity); err != nil {
-		logger.Error("Error editing user preference", "error", err)
+	if err != nil {
+		logger.ErrorContext(ctx, "error in update interest by admin", "interest_id", req.Id, "error", err)
 		return nil, err
 	}
-	logger.Info("preference edited successfully for user", "user_id", req.UserId)
 	return nil, nil
-
 }
 
-func (user *UserService) UserGetAddress(ctx context.Context, req *pb.GetUserById) (*pb.AddressResponse, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.Id)
-	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
-		return nil, err
+// interestCategory checks that the category an interest is put in exists.
+// It returns nil for id 0, which leaves the interest uncategorized.
+func (user *UserService) interestCategory(ctx context.Context, id int32) (*int, error) {
+	if id == 0 {
+		return nil, nil
 	}
-	address, err := user.adapters.GetAddressByProfileId(profile)
+	logger := logging.FromContext(ctx)
+	check, err := user.repo(ctx).GetInterestCategoryById(int(id))
 	if err != nil {
-		logger.Error("Error fetching address", "profile_id", profile, "error", err)
+		logger.ErrorContext(ctx, "error fetching interest category", "category_id", id, "error", err)
 		return nil, err
 	}
-	addressId := ""
-	if address.Id != uuid.Nil {
-		addressId = address.Id.String()
-	}
-	res := &pb.AddressResponse{
-		Id:       addressId,
-		Country:  address.Country,
-		State:    address.State,
-		District: address.District,
-		City:     address.City,
+	if check.Id == 0 {
+		logger.WarnContext(ctx, "interest category not found", "category_id", id)
+		return nil, status.Error(codes.NotFound, "please enter a valid category id")
 	}
-	logger.Info("Address successfully fetched", "user_id", req.Id)
-	return res, nil
+	return &check.Id, nil
 }
-func (user *UserService) GetAllGenderUser(ctx context.Context, req *pb.GetUserById) (*pb.GenderResponse, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.Id)
-	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
-		return nil, err
+
+func (user *UserService) AdminAddInterestCategory(ctx context.Context, req *pb.InterestCategoryRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	reqEntity := entities.InterestCategory{
+		Name:     req.Name,
+		Position: int(req.Position),
+	}
+	err := user.repo(ctx).AdminAddInterestCategory(reqEntity)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		logger.WarnContext(ctx, "interest category already exist", "category_name", req.Name)
+		return nil, status.Error(codes.AlreadyExists, "interest category already exist")
 	}
-	// genders, err := user.adapters.UserGetAllGender(profileId)
-	// if err != nil {
-	// 	return err
-	// }
-	// for _, gender := range genders {
-	// 	res := &pb.GenderResponse{
-	// 		Id:     int32(gender.GenderId),
-	// 		Gender: gender.GenderName,
-	// 	}
-	// 	if err := srv.Send(res); err != nil {
-	// 		return err
-	// 	}
-	// }
-	// return nil
-	// gender,err:=user.adapters.GetGenderByProfileId(profile)
-	// if err!=nil{
-	// 	return nil,err
-	// }
-	genders, err := user.adapters.UserGetAllGender(profile)
 	if err != nil {
-		logger.Error("error in fetching gender")
+		logger.ErrorContext(ctx, "error in add interest category by admin", "category_name", req.Name, "error", err)
 		return nil, err
 	}
-	res := &pb.GenderResponse{
-		Id:     int32(genders.GenderId),
-		Gender: genders.GenderName,
-	}
-	logger.Info("successfully fetched gender")
-	return res, nil
-
+	return nil, nil
 }
 
-func (user *UserService) GetAllPreference(ctx context.Context, req *pb.GetUserById) (*pb.PreferenceResponse, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.Id)
-	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
-		return nil, err
+func (user *UserService) AdminUpdateInterestCategory(ctx context.Context, req *pb.InterestCategoryResponse) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	reqEntity := entities.InterestCategory{
+		Id:       int(req.Id),
+		Name:     req.Name,
+		Position: int(req.Position),
+	}
+	err := user.repo(ctx).AdminUpdateInterestCategory(reqEntity)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		logger.WarnContext(ctx, "interest category already exist", "category_name", req.Name)
+		return nil, status.Error(codes.AlreadyExists, "interest category already exist")
 	}
-	preference, err := user.adapters.GetPreferenceByProfileId(profile)
 	if err != nil {
-		logger.Error("Error fetching preference", "profile_id", profile, "error", err)
+		logger.ErrorContext(ctx, "error in update interest category by admin", "category_id", req.Id, "error", err)
 		return nil, err
 	}
-	preferenceId := ""
-	if preference.Id != uuid.Nil {
-		preferenceId = preference.Id.String()
-	}
-	res := &pb.PreferenceResponse{
-		Id:         preferenceId,
-		Minage:     int32(preference.MinAge),
-		Maxage:     int32(preference.MaxAge),
-		Gender:     int32(preference.GenderId),
-		Desirecity: preference.DesireCity,
-	}
-	return res, nil
+	return nil, nil
 }
 
-func (user *UserService) AdminAddGender(ctx context.Context, req *pb.AddGenderRequest) (*pb.NoArg, error) {
-	reqEntity := entities.Gender{
-		Name: req.Gender,
-	}
-	check, err := user.adapters.GetGenderByName(req.Gender)
-	if err != nil {
-		logger.Error("error fetching gender name")
+func (user *UserService) AdminDeleteInterestCategory(ctx context.Context, req *pb.DeleteCatalogRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	if _, err := user.interestCategory(ctx, req.Id); err != nil {
 		return nil, err
 	}
-	if check.Name != "" {
-		logger.Warn("gender already exist")
-		return nil, fmt.Errorf("gender already exist")
-	}
-	err = user.adapters.AdminAddGender(reqEntity)
-	if err != nil {
-		logger.Error("error in add gender by admin")
+	if err := user.repo(ctx).AdminDeleteInterestCategory(int(req.Id)); err != nil {
+		logger.ErrorContext(ctx, "error deleting interest category", "category_id", req.Id, "error", err)
 		return nil, err
 	}
+	logger.InfoContext(ctx, "interest category deleted", "category_id", req.Id)
 	return nil, nil
 }
 
-func (user *UserService) UserAddPreference(ctx context.Context, req *pb.PreferenceRequest) (*pb.NoArg, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+func (user *UserService) GetAllInterestCategories(e *pb.NoArg, srv pb.UserService_GetAllInterestCategoriesServer) error {
+	logger := logging.FromContext(srv.Context())
+	categories, err := user.repo(srv.Context()).AdminGetAllInterestCategories()
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
-		return nil, err
+		logger.ErrorContext(srv.Context(), "error in fetching interest categories", "error", err)
+		return err
 	}
-	profileId, err := uuid.Parse(profile)
-	if err != nil {
-		logger.Error("Error parsing profile Id, profile_id", profile, "error", err)
+	for _, category := range categories {
+		res := &pb.InterestCategoryResponse{
+			Id:       int32(category.Id),
+			Name:     category.Name,
+			Position: int32(category.Position),
+		}
+		if err := srv.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (user *UserService) AdminUpsertTranslation(ctx context.Context, req *pb.TranslationRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx).With("entity", req.Entity, "entity_id", req.EntityId, "locale", req.Locale)
+	var exists bool
+	switch req.Entity {
+	case entities.TranslationInterest:
+		check, err := user.repo(ctx).GetInterestById(int(req.EntityId))
+		if err != nil {
+			logger.ErrorContext(ctx, "error fetching interest", "error", err)
+			return nil, err
+		}
+		exists = check.InterestId != 0
+	case entities.TranslationInterestCategory:
+		check, err := user.repo(ctx).GetInterestCategoryById(int(req.EntityId))
+		if err != nil {
+			logger.ErrorContext(ctx, "error fetching interest category", "error", err)
+			return nil, err
+		}
+		exists = check.Id != 0
+	case entities.TranslationGender:
+		check, err := user.repo(ctx).GetGenderById(int(req.EntityId))
+		if err != nil {
+			logger.ErrorContext(ctx, "error fetching gender", "error", err)
+			return nil, err
+		}
+		exists = check.GenderId != 0
+	}
+	if !exists {
+		logger.WarnContext(ctx, "translated entry not found")
+		return nil, status.Errorf(codes.NotFound, "%s %d not found", req.Entity, req.EntityId)
+	}
+	reqEntity := entities.Translation{
+		Entity:   req.Entity,
+		EntityId: int(req.EntityId),
+		Locale:   helper.NormalizeLocale(req.Locale),
+		Name:     req.Name,
+	}
+	if err := user.repo(ctx).UpsertTranslation(reqEntity); err != nil {
+		logger.ErrorContext(ctx, "error saving translation", "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "translation saved")
+	return nil, nil
+}
+
+func (user *UserService) AdminDeleteTranslation(ctx context.Context, req *pb.TranslationKey) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx).With("entity", req.Entity, "entity_id", req.EntityId, "locale", req.Locale)
+	reqEntity := entities.Translation{
+		Entity:   req.Entity,
+		EntityId: int(req.EntityId),
+		Locale:   helper.NormalizeLocale(req.Locale),
+	}
+	if err := user.repo(ctx).DeleteTranslation(reqEntity); err != nil {
+		logger.ErrorContext(ctx, "error deleting translation", "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "translation deleted")
+	return nil, nil
+}
+
+func (user *UserService) AdminUpdateGender(ctx context.Context, req *pb.GenderResponse) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	reqEntity := entities.Gender{
+		Id:   int(req.Id),
+		Name: req.Gender,
+	}
+	err := user.repo(ctx).AdminUpdateGender(reqEntity)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		logger.WarnContext(ctx, "gender already exist", "gender_name", req.Gender)
+		return nil, status.Error(codes.AlreadyExists, "gender already exist")
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "error in update gender by admin", "gender_id", req.Id, "error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (user *UserService) AdminDeleteInterest(ctx context.Context, req *pb.DeleteCatalogRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	check, err := user.repo(ctx).GetInterestById(int(req.Id))
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching interest", "interest_id", req.Id, "error", err)
+		return nil, err
+	}
+	if check.InterestId == 0 {
+		logger.WarnContext(ctx, "interest not found", "interest_id", req.Id)
+		return nil, status.Error(codes.NotFound, "please enter a valid interest id")
+	}
+	if err := user.repo(ctx).AdminDeleteInterest(int(req.Id)); err != nil {
+		logger.ErrorContext(ctx, "error deleting interest", "interest_id", req.Id, "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "interest deleted", "interest_id", req.Id)
+	return nil, nil
+}
+
+func (user *UserService) AdminMergeInterests(ctx context.Context, req *pb.MergeCatalogRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	for _, id := range []int32{req.SourceId, req.TargetId} {
+		check, err := user.repo(ctx).GetInterestById(int(id))
+		if err != nil {
+			logger.ErrorContext(ctx, "error fetching interest", "interest_id", id, "error", err)
+			return nil, err
+		}
+		if check.InterestId == 0 {
+			logger.WarnContext(ctx, "interest not found", "interest_id", id)
+			return nil, status.Errorf(codes.NotFound, "interest %d not found", id)
+		}
+	}
+	if err := user.repo(ctx).AdminMergeInterests(int(req.SourceId), int(req.TargetId)); err != nil {
+		logger.ErrorContext(ctx, "error merging interests", "source_id", req.SourceId, "target_id", req.TargetId, "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "interests merged", "source_id", req.SourceId, "target_id", req.TargetId)
+	return nil, nil
+}
+
+func (user *UserService) AdminDeleteGender(ctx context.Context, req *pb.DeleteCatalogRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	check, err := user.repo(ctx).GetGenderById(int(req.Id))
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching gender", "gender_id", req.Id, "error", err)
+		return nil, err
+	}
+	if check.GenderId == 0 {
+		logger.WarnContext(ctx, "gender not found", "gender_id", req.Id)
+		return nil, status.Error(codes.NotFound, "please enter a valid gender id")
+	}
+	if err := user.repo(ctx).AdminDeleteGender(int(req.Id)); err != nil {
+		logger.ErrorContext(ctx, "error deleting gender", "gender_id", req.Id, "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "gender deleted", "gender_id", req.Id)
+	return nil, nil
+}
+
+func (user *UserService) AdminMergeGenders(ctx context.Context, req *pb.MergeCatalogRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	for _, id := range []int32{req.SourceId, req.TargetId} {
+		check, err := user.repo(ctx).GetGenderById(int(id))
+		if err != nil {
+			logger.ErrorContext(ctx, "error fetching gender", "gender_id", id, "error", err)
+			return nil, err
+		}
+		if check.GenderId == 0 {
+			logger.WarnContext(ctx, "gender not found", "gender_id", id)
+			return nil, status.Errorf(codes.NotFound, "gender %d not found", id)
+		}
+	}
+	if err := user.repo(ctx).AdminMergeGenders(int(req.SourceId), int(req.TargetId)); err != nil {
+		logger.ErrorContext(ctx, "error merging genders", "source_id", req.SourceId, "target_id", req.TargetId, "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "genders merged", "source_id", req.SourceId, "target_id", req.TargetId)
+	return nil, nil
+}
+
+func (user *UserService) GetAllInterest(req *pb.InterestFilter, srv pb.UserService_GetAllInterestServer) error {
+	logger := logging.FromContext(srv.Context())
+	interests, err := user.repo(srv.Context()).AdminGetAllInterest(int(req.CategoryId), helper.Locales(srv.Context()))
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error in fetching get all interest")
+		return err
+	}
+	for _, interest := range interests {
+		res := &pb.InterestResponse{
+			Id:         int32(interest.InterestId),
+			Interest:   interest.InterestName,
+			CategoryId: int32(interest.CategoryId),
+			Category:   interest.CategoryName,
+		}
+		err := srv.Send(res)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (user *UserService) GetAllGender(e *pb.NoArg, srv pb.UserService_GetAllGenderServer) error {
+	logger := logging.FromContext(srv.Context())
+	genders, err := user.repo(srv.Context()).AdminGetAllGender(helper.Locales(srv.Context()))
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error in fetching get all gender")
+		return err
+	}
+	for _, gender := range genders {
+		res := &pb.GenderResponse{
+			Id:     int32(gender.Id),
+			Gender: gender.Name,
+		}
+		err := srv.Send(res)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (user *UserService) AddInterestUser(ctx context.Context, req *pb.DeleteInterestRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	check, err := user.repo(ctx).GetInterestById(int(req.InterestId))
+	if err != nil {
+		logger.ErrorContext(ctx, "error fectching interest by ID", "interest_id", req.InterestId, "error", err)
+		return nil, err
+	}
+	if check.InterestId == 0 {
+		logger.WarnContext(ctx, "Invalid interest ID provided", "interest_id", req.InterestId)
+
+		return nil, fmt.Errorf("please enter a valid interest id")
+	}
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	loggerctx := logger.With("user_id", req.UserId)
+	profileId, err := uuid.Parse(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error parsing profile Id", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	reqEntity := entities.UserInterests{
+		ProfileId:  profileId,
+		InterestId: int(req.InterestId),
+	}
+	err = user.repo(ctx).UserAddInterestWithin(reqEntity, user.maxInterests)
+	if errors.Is(err, adapters.ErrTooManyInterests) {
+		loggerctx.WarnContext(ctx, "user has the maximum number of interests", "max_interests", user.maxInterests)
+		return nil, status.Errorf(codes.FailedPrecondition, "you can add at most %d interests", user.maxInterests)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		loggerctx.WarnContext(ctx, "interest already added for user", "interest_id", req.InterestId)
+		return nil, status.Error(codes.AlreadyExists, "you already have added this interest please add a new one")
+	}
+	if err != nil {
+		loggerctx.ErrorContext(ctx, "Error adding user interest", "interest_id", req.InterestId, "error", err)
+		return nil, err
+	}
+	loggerctx.InfoContext(ctx, "interest added successfully for user", "interest_id", req.InterestId)
+	return nil, nil
+}
+
+func (user *UserService) AddGenderUser(ctx context.Context, req *pb.UpdateGenderRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	check, err := user.repo(ctx).GetGenderById(int(req.GenderId))
+	if err != nil {
+		logger.ErrorContext(ctx, "Error to fectching gender id", "gender_id", req.GenderId, "error", err)
+		return nil, err
+	}
+	if check.GenderId == 0 {
+		logger.ErrorContext(ctx, "Error gender_id is not correct ")
+		return nil, fmt.Errorf("please enter a valid gender id")
+	}
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	loggerctx := logger.With("user_id", req.UserId)
+	check1, err := user.repo(ctx).GetUserGenderById(profile, int(req.GenderId))
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching the gender_id", "gender_Id", req.GenderId, "error", err)
+		return nil, err
+	}
+	if check1.GenderId != 0 {
+		logger.ErrorContext(ctx, "error gender is already added")
+		return nil, fmt.Errorf("you already have added this gender ")
+
+	}
+	profileId, err := uuid.Parse(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error parsing profile Id", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	reqEntity := entities.UserGenders{
+		ProfileId: profileId,
+		GenderId:  int(req.GenderId),
+	}
+	if err := user.repo(ctx).UserAddGender(reqEntity); err != nil {
+		loggerctx.ErrorContext(ctx, "Error adding user gender", "gender_id", req.GenderId, "error", err)
+
+		return nil, err
+	}
+	loggerctx.InfoContext(ctx, "gender added successfully for user", "gender_id", req.GenderId)
+	return nil, nil
+}
+
+func (user *UserService) DeleteInterestUser(ctx context.Context, req *pb.DeleteInterestRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	profileId, err := uuid.Parse(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error parsing profile Id", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	loggerctx := logger.With("user_id", req.UserId)
+	reqEntity := entities.UserInterests{
+		ProfileId:  profileId,
+		InterestId: int(req.InterestId),
+	}
+	if err := user.repo(ctx).UserDeleteInterest(reqEntity); err != nil {
+		loggerctx.ErrorContext(ctx, "Error deleting user interest", "interest_id", req.InterestId, "error", err)
+		return nil, err
+	}
+	loggerctx.InfoContext(ctx, "interest deleted successfully for user", "interest_id", req.InterestId)
+	return nil, nil
+}
+
+// SetUserInterests replaces all the interests of a user at once.
+func (user *UserService) SetUserInterests(ctx context.Context, req *pb.SetUserInterestsRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.UserId)
+	var interestIds []int
+	seen := make(map[int32]bool)
+	for _, id := range req.InterestIds {
+		if !seen[id] {
+			seen[id] = true
+			interestIds = append(interestIds, int(id))
+		}
+	}
+	if len(interestIds) > user.maxInterests {
+		logger.WarnContext(ctx, "too many interests", "count", len(interestIds), "max_interests", user.maxInterests)
+		return nil, status.Errorf(codes.InvalidArgument, "you can add at most %d interests", user.maxInterests)
+	}
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "error", err)
+		return nil, err
+	}
+	err = user.repo(ctx).SetUserInterests(profile, interestIds)
+	if errors.Is(err, adapters.ErrUnknownInterest) {
+		logger.WarnContext(ctx, "unknown interest in set", "interest_ids", interestIds)
+		return nil, status.Error(codes.NotFound, "please enter valid interest ids")
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "error setting user interests", "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "user interests replaced", "count", len(interestIds))
+	return nil, nil
+}
+
+func (user *UserService) GetAllInterestsUser(req *pb.GetUserById, srv pb.UserService_GetAllInterestsUserServer) error {
+	logger := logging.FromContext(srv.Context())
+	profileId, err := user.repo(srv.Context()).GetProfileIdByUserId(req.Id)
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		return err
+	}
+	interests, err := user.repo(srv.Context()).UserGetAllInterest(profileId, helper.Locales(srv.Context()))
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "Error in fetching interests")
+		return err
+	}
+	for _, interest := range interests {
+		res := &pb.InterestResponse{
+			Id:       int32(interest.Id),
+			Interest: interest.Interest,
+		}
+
+		if err := srv.Send(res); err != nil {
+			return err
+		}
+	}
+	logger.InfoContext(srv.Context(), "fetching interest is successful")
+	return nil
+}
+
+func (user *UserService) UserAddAddress(ctx context.Context, req *pb.AddAddressRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	profileId, err := uuid.Parse(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error parsing profile Id", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	address, err := user.repo(ctx).GetAddressByProfileId(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error fetching address", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	if address.Country != "" {
+		logger.ErrorContext(ctx, "address is already exists")
+		return nil, fmt.Errorf("you have already added an address please edit the existing")
+	}
+	reqEntity := entities.Address{
+		Country:   req.Country,
+		State:     req.State,
+		District:  req.District,
+		City:      req.City,
+		ProfileId: profileId,
+	}
+	if err := user.repo(ctx).UserAddAddress(reqEntity); err != nil {
+		logger.ErrorContext(ctx, "Error adding user address", "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "address added successfully for user")
+	return nil, nil
+}
+
+func (user *UserService) UserEditAddress(ctx context.Context, req *pb.AddressResponse) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	profileId, err := uuid.Parse(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error parsing profile Id", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	reqEntity := entities.Address{
+		Country:   req.Country,
+		State:     req.State,
+		District:  req.District,
+		City:      req.City,
+		ProfileId: profileId,
+	}
+	if err := user.repo(ctx).UserEditAddress(reqEntity); err != nil {
+		logger.ErrorContext(ctx, "Error editing user address", "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "address edited successfully for user", "user_id", req.UserId)
+	return nil, nil
+}
+
+func (user *UserService) UserEditPreference(ctx context.Context, req *pb.PreferenceResponse) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	profileId, err := uuid.Parse(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error parsing profile Id", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	genders := preferenceGenders(req.Genders, req.Gender)
+	reqEntity := entities.Preference{
+		MinAge:        int(req.Minage),
+		MaxAge:        int(req.Maxage),
+		GenderId:      genders[0],
+		GenderIds:     genders,
+		DesireCity:    req.Desirecity,
+		MaxDistanceKm: int(req.MaxDistanceKm),
+		ProfileId:     profileId,
+	}
+	err = user.repo(ctx).UserEditPreference(reqEntity)
+	if errors.Is(err, adapters.ErrUnknownGender) {
+		logger.WarnContext(ctx, "unknown gender in preference", "gender_ids", genders)
+		return nil, status.Error(codes.NotFound, "please enter valid gender ids")
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Error editing user preference", "error", err)
+		return nil, err
+	}
+	if user.resetSeen {
+		if err := user.resetSeenUsers(ctx, profile); err != nil {
+			logger.WarnContext(ctx, "error resetting seen users after preference change", "user_id", req.UserId, "error", err)
+		}
+	}
+	logger.InfoContext(ctx, "preference edited successfully for user", "user_id", req.UserId)
+	return nil, nil
+
+}
+
+// preferenceGenders returns the accepted genders of a preference request
+// without duplicates. Clients that predate gender sets only send gender.
+func preferenceGenders(genders []int32, gender int32) []int {
+	if len(genders) == 0 {
+		genders = []int32{gender}
+	}
+	res := make([]int, 0, len(genders))
+	seen := make(map[int32]bool, len(genders))
+	for _, g := range genders {
+		if !seen[g] {
+			seen[g] = true
+			res = append(res, int(g))
+		}
+	}
+	return res
+}
+
+func (user *UserService) UpdateLocation(ctx context.Context, req *pb.UpdateLocationRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	location := helperstruct.Location{Latitude: req.Latitude, Longitude: req.Longitude}
+	if err := user.repo(ctx).UpdateLocation(location, profile); err != nil {
+		logger.ErrorContext(ctx, "error updating location", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (user *UserService) UserGetAddress(ctx context.Context, req *pb.GetUserById) (*pb.AddressResponse, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		return nil, err
+	}
+	address, err := user.repo(ctx).GetAddressByProfileId(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error fetching address", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	addressId := ""
+	if address.Id != uuid.Nil {
+		addressId = address.Id.String()
+	}
+	res := &pb.AddressResponse{
+		Id:       addressId,
+		Country:  address.Country,
+		State:    address.State,
+		District: address.District,
+		City:     address.City,
+	}
+	logger.InfoContext(ctx, "Address successfully fetched", "user_id", req.Id)
+	return res, nil
+}
+func (user *UserService) GetAllGenderUser(req *pb.GetUserById, srv pb.UserService_GetAllGenderUserServer) error {
+	logger := logging.FromContext(srv.Context())
+	profile, err := user.repo(srv.Context()).GetProfileIdByUserId(req.Id)
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		return err
+	}
+	genders, err := user.repo(srv.Context()).UserGetAllGender(profile)
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error in fetching gender", "error", err)
+		return err
+	}
+	for _, gender := range genders {
+		res := &pb.GenderResponse{
+			Id:     int32(gender.GenderId),
+			Gender: gender.GenderName,
+		}
+		if err := srv.Send(res); err != nil {
+			return err
+		}
+	}
+	logger.InfoContext(srv.Context(), "successfully fetched gender")
+	return nil
+}
+
+func (user *UserService) RemoveGenderUser(ctx context.Context, req *pb.UpdateGenderRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	check, err := user.repo(ctx).GetUserGenderById(profile, int(req.GenderId))
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching the gender_id", "gender_id", req.GenderId, "error", err)
+		return nil, err
+	}
+	if check.GenderId == 0 {
+		logger.WarnContext(ctx, "gender is not added for user", "gender_id", req.GenderId)
+		return nil, fmt.Errorf("you have not added this gender")
+	}
+	if err := user.repo(ctx).UserRemoveGender(check); err != nil {
+		logger.ErrorContext(ctx, "Error removing user gender", "gender_id", req.GenderId, "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "gender removed successfully for user", "gender_id", req.GenderId)
+	return nil, nil
+}
+
+func (user *UserService) GetAllPreference(ctx context.Context, req *pb.GetUserById) (*pb.PreferenceResponse, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		return nil, err
+	}
+	preference, err := user.repo(ctx).GetPreferenceByProfileId(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error fetching preference", "profile_id", profile, "error", err)
+		return nil, err
+	}
+	preferenceId := ""
+	if preference.Id != uuid.Nil {
+		preferenceId = preference.Id.String()
+	}
+	genders := make([]int32, len(preference.GenderIds))
+	for i, g := range preference.GenderIds {
+		genders[i] = int32(g)
+	}
+	res := &pb.PreferenceResponse{
+		Id:            preferenceId,
+		Minage:        int32(preference.MinAge),
+		Maxage:        int32(preference.MaxAge),
+		Gender:        int32(preference.GenderId),
+		Genders:       genders,
+		Desirecity:    preference.DesireCity,
+		MaxDistanceKm: int32(preference.MaxDistanceKm),
+	}
+	return res, nil
+}
+
+func (user *UserService) AdminAddGender(ctx context.Context, req *pb.AddGenderRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	reqEntity := entities.Gender{
+		Name: req.Gender,
+	}
+	err := user.repo(ctx).AdminAddGender(reqEntity)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		logger.WarnContext(ctx, "gender already exist", "gender_name", req.Gender)
+		return nil, status.Error(codes.AlreadyExists, "gender already exist")
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "error in add gender by admin", "error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (user *UserService) UserAddPreference(ctx context.Context, req *pb.PreferenceRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		return nil, err
+	}
+	profileId, err := uuid.Parse(profile)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error parsing profile Id", "profile_id", profile, "error", err)
 		return nil, err
 	}
-	preference, err := user.adapters.GetPreferenceByProfileId(profile)
+	preference, err := user.repo(ctx).GetPreferenceByProfileId(profile)
 	if err != nil {
-		logger.Error("Error fetching preference", "profile_id", profile, "error", err)
+		logger.ErrorContext(ctx, "Error fetching preference", "profile_id", profile, "error", err)
 		return nil, err
 	}
 	if preference.DesireCity != "" {
-		logger.Error("preference is already exists")
+		logger.ErrorContext(ctx, "preference is already exists")
 		return nil, fmt.Errorf("you have already added a preference please edit the existing")
 	}
+	genders := preferenceGenders(req.Genders, req.Gender)
 	reqEntity := entities.Preference{
-		MinAge:     int(req.Minage),
-		MaxAge:     int(req.Maxage),
-		GenderId:   int(req.Gender),
-		DesireCity: req.Desirecity,
-		ProfileId:  profileId,
+		MinAge:        int(req.Minage),
+		MaxAge:        int(req.Maxage),
+		GenderId:      genders[0],
+		GenderIds:     genders,
+		DesireCity:    req.Desirecity,
+		MaxDistanceKm: int(req.MaxDistanceKm),
+		ProfileId:     profileId,
+	}
+	err = user.repo(ctx).UserAddPreference(reqEntity)
+	if errors.Is(err, adapters.ErrUnknownGender) {
+		logger.WarnContext(ctx, "unknown gender in preference", "gender_ids", genders)
+		return nil, status.Error(codes.NotFound, "please enter valid gender ids")
 	}
-	if err := user.adapters.UserAddPreference(reqEntity); err != nil {
-		logger.Error("error in adding preference ", "user_id", req.UserId, "error", err)
+	if err != nil {
+		logger.ErrorContext(ctx, "error in adding preference ", "user_id", req.UserId, "error", err)
 		return nil, err
 	}
 	return nil, nil
@@ -634,9 +1545,10 @@ func (user *UserService) UserAddPreference(ctx context.Context, req *pb.Preferen
 }
 
 func (user *UserService) GetUser(ctx context.Context, req *pb.GetUserById) (*pb.UserSignupResponse, error) {
-	userData, err := user.adapters.GetUserById(req.Id)
+	logger := logging.FromContext(ctx)
+	userData, err := user.repo(ctx).GetUserById(req.Id)
 	if err != nil {
-		logger.Error("error in fetching userid", "used_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error in fetching userid", "used_id", req.Id, "error", err)
 		return nil, err
 	}
 	res := &pb.UserSignupResponse{
@@ -649,14 +1561,15 @@ func (user *UserService) GetUser(ctx context.Context, req *pb.GetUserById) (*pb.
 }
 
 func (user *UserService) UserUploadProfileImage(ctx context.Context, req *pb.UserImageRequest) (*pb.UserImageResponse, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
 		return nil, err
 	}
-	url, err := user.usecases.UploadImage(req, profile)
+	url, err := user.usecases.UploadImage(ctx, req, profile)
 	if err != nil {
-		logger.Error("error in uploadimage on usecase")
+		logger.ErrorContext(ctx, "error in uploadimage on usecase")
 		return nil, err
 	}
 	res := &pb.UserImageResponse{
@@ -665,15 +1578,108 @@ func (user *UserService) UserUploadProfileImage(ctx context.Context, req *pb.Use
 	return res, nil
 }
 
+// maxImageSize caps the total number of bytes accepted by
+// UploadProfileImageStream.
+const maxImageSize = 10 << 20
+
+var errImageTooLarge = status.Errorf(codes.ResourceExhausted, "image size exceeds the limit of %d bytes", maxImageSize)
+
+func (user *UserService) UploadProfileImageStream(srv pb.UserService_UploadProfileImageStreamServer) error {
+	logger := logging.FromContext(srv.Context())
+	req, err := srv.Recv()
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error receiving image metadata", "error", err)
+		return err
+	}
+	// The fields of the metadata are checked by the validation interceptor.
+	meta := req.GetMetadata()
+	if meta == nil {
+		logger.WarnContext(srv.Context(), "image stream did not start with metadata")
+		return status.Error(codes.InvalidArgument, "the first message must carry the image metadata")
+	}
+	if meta.Size > maxImageSize {
+		logger.WarnContext(srv.Context(), "image exceeds the maximum size", "user_id", meta.UserId, "size", meta.Size)
+		return errImageTooLarge
+	}
+	profile, err := user.repo(srv.Context()).GetProfileIdByUserId(meta.UserId)
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error fetching profile ID by user ID", "user_id", meta.UserId, "error", err)
+		return err
+	}
+	pr, pw := io.Pipe()
+	rejected := make(chan error, 1)
+	go receiveImageChunks(srv, pw, meta, rejected)
+	url, err := user.usecases.UploadImageStream(srv.Context(), meta.ObjectName, meta.ContentType, pr, profile)
+	if err != nil {
+		pr.CloseWithError(err)
+		select {
+		case err = <-rejected:
+			// The upload failed because the stream was rejected; its
+			// status is what the client needs to see.
+		default:
+		}
+		logger.ErrorContext(srv.Context(), "error in streaming image upload on usecase", "user_id", meta.UserId, "error", err)
+		return err
+	}
+	return srv.SendAndClose(&pb.UserImageResponse{
+		Url: url,
+	})
+}
+
+// receiveImageChunks copies the chunks of srv into pw. The pipe is closed
+// with an error when the stream breaks, grows beyond maxImageSize or ends
+// with a checksum that does not match the metadata, so the reader on the
+// other side never sees a clean EOF for a rejected image.
+func receiveImageChunks(srv pb.UserService_UploadProfileImageStreamServer, pw *io.PipeWriter, meta *pb.UploadImageMetadata, rejected chan<- error) {
+	// The error is sent before the pipe is closed, so it is waiting once the
+	// reader sees the pipe fail.
+	reject := func(err error) {
+		rejected <- err
+		pw.CloseWithError(err)
+	}
+	hash := sha256.New()
+	var total int64
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			reject(err)
+			return
+		}
+		chunk := req.GetChunk()
+		total += int64(len(chunk))
+		if total > maxImageSize {
+			reject(errImageTooLarge)
+			return
+		}
+		hash.Write(chunk)
+		if _, err := pw.Write(chunk); err != nil {
+			return
+		}
+	}
+	if meta.Size != 0 && total != meta.Size {
+		reject(status.Errorf(codes.InvalidArgument, "received %d bytes but %d were announced", total, meta.Size))
+		return
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != meta.Checksum {
+		reject(status.Error(codes.InvalidArgument, "image checksum mismatch"))
+		return
+	}
+	pw.Close()
+}
+
 func (user *UserService) UserGetProfilePic(ctx context.Context, req *pb.GetUserById) (*pb.UserImageResponse, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.Id)
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.Id)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	image, err := user.adapters.GetProfilePic(profile)
+	image, err := user.repo(ctx).GetProfilePic(profile)
 	if err != nil {
-		logger.Error("error in fetching profile pic")
+		logger.ErrorContext(ctx, "error in fetching profile pic")
 		return nil, err
 	}
 	return &pb.UserImageResponse{
@@ -682,22 +1688,20 @@ func (user *UserService) UserGetProfilePic(ctx context.Context, req *pb.GetUserB
 }
 
 func (user *UserService) UserAddAge(ctx context.Context, req *pb.UserAgeRequest) (*pb.NoArg, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.UserId)
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.UserId)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.UserId, "error", err)
 		return nil, err
 	}
 
-	layout := "2006-01-02T15:04:05.999999Z"
-	dob, err := time.Parse(layout, req.Dob)
+	dob, err := helper.ParseDOB(req.Dob)
 	if err != nil {
-		logger.Warn("invalid time format")
+		logger.WarnContext(ctx, "invalid time format")
 		return &pb.NoArg{}, fmt.Errorf("please provide time in appropriate format")
 	}
-	age := helper.CalculateAge(dob)
-
-	if err := user.adapters.UpdateAge(age, profile); err != nil {
-		logger.Error("error in setting age ")
+	if err := user.repo(ctx).UpdateDateOfBirth(dob, profile); err != nil {
+		logger.ErrorContext(ctx, "error in setting date of birth", "user_id", req.UserId, "error", err)
 		return nil, err
 	}
 	return nil, nil
@@ -705,14 +1709,15 @@ func (user *UserService) UserAddAge(ctx context.Context, req *pb.UserAgeRequest)
 }
 
 func (user *UserService) UserGetAge(ctx context.Context, req *pb.GetUserById) (*pb.UserAgeResponse, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.Id)
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.Id)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	age, err := user.adapters.GetAge(profile)
+	age, err := user.repo(ctx).GetAge(profile)
 	if err != nil {
-		logger.Error("error in fetching age", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error in fetching age", "user_id", req.Id, "error", err)
 		return nil, err
 	}
 	return &pb.UserAgeResponse{
@@ -721,92 +1726,102 @@ func (user *UserService) UserGetAge(ctx context.Context, req *pb.GetUserById) (*
 }
 
 func (user *UserService) HomePage(ctx context.Context, req *pb.GetUserById) (*pb.HomeResponse, error) {
-	profile, err := user.adapters.GetProfileIdByUserId(req.Id)
+	logger := logging.FromContext(ctx)
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.Id)
 	if err != nil {
-		logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	preference, err := user.adapters.FetchPreference(profile)
+	preference, err := user.repo(ctx).FetchPreference(profile)
 	if err != nil {
-		logger.Error("error fetching preference by userId", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error fetching preference by userId", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	userData, err := user.adapters.FetchUser(profile)
+	userData, err := user.repo(ctx).FetchUser(profile)
 	if err != nil {
-		logger.Error("error fetching userData by userId", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error fetching userData by userId", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	interestData, err := user.adapters.FetchInterests(profile)
+	// Interests of both users are translated alike, so they still compare
+	// equal when scoring.
+	locales := helper.Locales(ctx)
+	interestData, err := user.repo(ctx).FetchInterests(profile, locales)
 	if err != nil {
-		logger.Error("error fetching interests by userId", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error fetching interests by userId", "user_id", req.Id, "error", err)
 		return nil, err
 	}
 	end := len(interestData) - 1
-	users, err := user.adapters.FetchUsers(preference.MinAge, preference.MaxAge, preference.Gender, profile)
+	displayedUserIds, err := user.getDisplayedUserIds(ctx, profile)
 	if err != nil {
-		logger.Error("error to fetching users based on preferences", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-
-	displayedUserIds, err := getDisplayedUserIds(profile)
+	excluded := make([]string, 0, len(displayedUserIds))
+	for id := range displayedUserIds {
+		excluded = append(excluded, id)
+	}
+	query := helperstruct.CandidateQuery{
+		ProfileId:      profile,
+		MinAge:         preference.MinAge,
+		MaxAge:         preference.MaxAge,
+		GenderIds:      preference.Genders,
+		City:           preference.DesireCity,
+		ExcludeUserIds: excluded,
+		ExcludeLikedBy: req.Id,
+		ExcludeBlocked: true,
+		Reciprocal:     true,
+		Locales:        locales,
+	}
+	if user.likedRecycle > 0 {
+		query.LikedSince = time.Now().Add(-user.likedRecycle)
+	}
+	if userData.Latitude != nil && userData.Longitude != nil {
+		query.Origin = &helperstruct.Location{Latitude: *userData.Latitude, Longitude: *userData.Longitude}
+		if preference.MaxDistanceKm > 0 {
+			query.City = ""
+			query.MaxDistanceKm = preference.MaxDistanceKm
+		}
+	}
+	users, err := user.repo(ctx).FetchCandidates(query)
 	if err != nil {
+		logger.ErrorContext(ctx, "error to fetching users based on preferences", "user_id", req.Id, "error", err)
 		return nil, err
 	}
-	fmt.Println("display :", displayedUserIds)
 
+	// Seen users are already excluded by the query, so every candidate is
+	// scored.
+	profileIds := make([]string, 0, len(users))
+	for _, u := range users {
+		profileIds = append(profileIds, u.ProfileId)
+	}
+	cards, err := user.repo(ctx).FetchCandidateCards(profileIds, locales)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching candidate cards", "user_id", req.Id, "error", err)
+		return nil, err
+	}
 	scores := []float64{}
 	matchUsers := []helperstruct.Home{}
 	for _, u := range users {
-		userProfile, err := user.adapters.GetProfileIdByUserId(u.Id)
-		if err != nil {
-			logger.Error("error fetching profile ID by user ID", "user_id", req.Id, "error", err)
-			return nil, err
-		}
-		image, err := user.adapters.FetchImages(userProfile)
-		if err != nil {
-			logger.Error("error fetching images ", "user_id", u.Id)
-			return nil, err
-		}
-		u.Images = image
-
-		interests, err := user.adapters.FetchInterests(userProfile)
-		if err != nil {
-			logger.Error("error fetching images", "user_id", u.Id)
-			return nil, err
-		}
-		userPreference, err := user.adapters.FetchPreference(userProfile)
-		if err != nil {
-			logger.Error("error fetching preference", "user_id", u.Id)
-			return nil, err
-		}
-		if userPreference.DesireCity != preference.DesireCity {
-			continue
-		}
-
-		u.Interests = interests
+		u.Images = cards[u.ProfileId].Images
+		u.Interests = cards[u.ProfileId].Interests
 		interestScore := 0
-		for _, interest := range interests {
+		for _, interest := range u.Interests {
 			search := helper.SearchForInterest(interestData, interest, 0, end)
 			if !search {
 				continue
 			}
 			interestScore++
 		}
-		fmt.Println("akshay :", u.Name)
-		if !displayedUserIds[u.Id] {
-			AgeScore := helper.Abs(u.Age - userData.Age)
-			score := float64(AgeScore) + 2*float64(interestScore)
-			scores = append(scores, score)
-			matchUsers = append(matchUsers, u)
-
-			displayedUserIds[u.Id] = true
-		}
-
+		AgeScore := helper.Abs(u.Age - userData.Age)
+		score := float64(AgeScore) + 2*float64(interestScore)
+		scores = append(scores, score)
+		matchUsers = append(matchUsers, u)
 	}
 	if len(matchUsers) == 0 {
-		logger.Error("there is no new recommendations")
+		logger.ErrorContext(ctx, "there is no new recommendations")
+		metrics.HomePageRequests.WithLabelValues(metrics.HomePageEmpty).Inc()
 		return nil, fmt.Errorf("no new recommendations available")
 	}
+	metrics.HomePageRequests.WithLabelValues(metrics.HomePageRecommended).Inc()
 
 	helper.QuickSort(scores, matchUsers, 0, len(scores)-1)
 
@@ -815,7 +1830,7 @@ func (user *UserService) HomePage(ctx context.Context, req *pb.GetUserById) (*pb
 
 	seen := map[string]bool{id: true}
 
-	err = updateDisplayedUserIds(profile, seen)
+	err = user.updateDisplayedUserIds(ctx, profile, seen)
 	if err != nil {
 		return nil, fmt.Errorf("here is the problem in update displayUserId")
 	}
@@ -830,44 +1845,269 @@ func (user *UserService) HomePage(ctx context.Context, req *pb.GetUserById) (*pb
 		Image:     matchUsers[0].Images,
 		Interests: matchUsers[0].Interests,
 	}
-
-	fmt.Println("home :", homeResponse)
+	if matchUsers[0].DistanceKm != nil {
+		distance := helper.RoundDistanceKm(*matchUsers[0].DistanceKm)
+		homeResponse.DistanceKm = &distance
+	}
+	details, err := user.repo(ctx).GetProfileDetails(matchUsers[0].ProfileId)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile details", "user_id", id, "error", err)
+		return nil, err
+	}
+	homeResponse.Profile = profileDetails(details)
 
 	return homeResponse, nil
 }
 
-func getDisplayedUserIds(userID string) (map[string]bool, error) {
-	displayedUserIdsKey := fmt.Sprintf("displayed_user_ids:%s", userID)
+// getDisplayedUserIds returns the users recently shown to a profile. Users
+// shown longer ago than the recycle period are dropped, so they can be
+// recommended again.
+func (user *UserService) getDisplayedUserIds(ctx context.Context, profileId string) (map[string]bool, error) {
+	seenKey := seenUsersKey(profileId)
 	displayedUserIds := make(map[string]bool)
 
-	userIdsInRedis, err := redisClient.SMembers(displayedUserIdsKey).Result()
+	cutoff := time.Now().Add(-user.seenRecycle).Unix()
+	var members *redis.StringSliceCmd
+	ctx, span := tracing.Start(ctx, "redis.ZRange", attribute.String("redis.key", seenKey))
+	_, err := user.redis.WithContext(ctx).TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(seenKey, "-inf", fmt.Sprintf("(%d", cutoff))
+		members = pipe.ZRange(seenKey, 0, -1)
+		return nil
+	})
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, userIdStr := range userIdsInRedis {
+	for _, userIdStr := range members.Val() {
 		displayedUserIds[userIdStr] = true
 	}
 
 	return displayedUserIds, nil
 }
 
-func updateDisplayedUserIds(userID string, displayedUserIds map[string]bool) error {
-	displayedUserIdsKey := fmt.Sprintf("displayed_user_ids:%s", userID)
+func (user *UserService) updateDisplayedUserIds(ctx context.Context, profileId string, displayedUserIds map[string]bool) error {
+	seenKey := seenUsersKey(profileId)
+	now := float64(time.Now().Unix())
+	seen := make([]redis.Z, 0, len(displayedUserIds))
 	userIds := make([]interface{}, 0, len(displayedUserIds))
 
 	for userId := range displayedUserIds {
+		seen = append(seen, redis.Z{Score: now, Member: userId})
 		userIds = append(userIds, userId)
 	}
 
-	_, err := redisClient.SAdd(displayedUserIdsKey, userIds...).Result()
+	// The history keeps the order users were shown in, so the last of them
+	// can be rewound; the sorted set says when each user was last shown.
+	// Both expire once everything in them would have been recycled.
+	historyKey := swipeHistoryKey(profileId)
+	ctx, span := tracing.Start(ctx, "redis.ZAdd", attribute.String("redis.key", seenKey))
+	_, err := user.redis.WithContext(ctx).TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(seenKey, seen...)
+		pipe.Expire(seenKey, user.seenRecycle)
+		pipe.LPush(historyKey, userIds...)
+		pipe.LTrim(historyKey, 0, maxSwipeHistory-1)
+		pipe.Expire(historyKey, user.seenRecycle)
+		for userId := range displayedUserIds {
+			user.indexSeenBy(pipe, userId, profileId)
+		}
+		return nil
+	})
+	tracing.End(span, err)
+	return err
+}
+
+// seenUsersKey holds the users shown to a profile, scored by the unix time
+// they were last shown at.
+func seenUsersKey(profileId string) string {
+	return fmt.Sprintf("seen_users:%s", profileId)
+}
+
+// seenByKey is the reverse of seenUsersKey and swipeHistoryKey: the
+// profiles a user was shown to.
+func seenByKey(userId string) string {
+	return fmt.Sprintf("seen_by:%s", userId)
+}
+
+// indexSeenBy records in seenByKey that userId was shown to profileId. The
+// index expires with the seen users it points to.
+func (user *UserService) indexSeenBy(pipe redis.Pipeliner, userId, profileId string) {
+	pipe.SAdd(seenByKey(userId), profileId)
+	pipe.Expire(seenByKey(userId), user.seenRecycle)
+}
+
+// legacySeenUsersPattern matches the sets that held shown users before they
+// were timestamped.
+const legacySeenUsersPattern = "displayed_user_ids:*"
+
+// MigrateSeenSets moves the users in sets written before shown users were
+// timestamped into the sorted sets, as if they had been shown now, and
+// returns how many sets were moved.
+func (user *UserService) MigrateSeenSets(ctx context.Context) (int, error) {
+	rdb := user.redis.WithContext(ctx)
+	migrated := 0
+	var cursor uint64
+	for {
+		keys, next, err := rdb.Scan(cursor, legacySeenUsersPattern, 100).Result()
+		if err != nil {
+			return migrated, err
+		}
+		for _, key := range keys {
+			userIds, err := rdb.SMembers(key).Result()
+			if err != nil {
+				return migrated, err
+			}
+			profile := strings.TrimPrefix(key, "displayed_user_ids:")
+			seenKey := seenUsersKey(profile)
+			now := float64(time.Now().Unix())
+			seen := make([]redis.Z, 0, len(userIds))
+			for _, userId := range userIds {
+				seen = append(seen, redis.Z{Score: now, Member: userId})
+			}
+			_, err = rdb.TxPipelined(func(pipe redis.Pipeliner) error {
+				if len(seen) > 0 {
+					pipe.ZAddNX(seenKey, seen...)
+					pipe.Expire(seenKey, user.seenRecycle)
+				}
+				for _, userId := range userIds {
+					user.indexSeenBy(pipe, userId, profile)
+				}
+				pipe.Del(key)
+				return nil
+			})
+			if err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+		if next == 0 {
+			return migrated, nil
+		}
+		cursor = next
+	}
+}
+
+// resetSeenUsers lets the users a profile passed on be recommended again.
+// Liked users stay out, since they are left out of the candidates by the
+// database rather than by the seen users.
+func (user *UserService) resetSeenUsers(ctx context.Context, profileId string) error {
+	seenKey := seenUsersKey(profileId)
+	ctx, span := tracing.Start(ctx, "redis.Del", attribute.String("redis.key", seenKey))
+	err := user.redis.WithContext(ctx).Del(seenKey, swipeHistoryKey(profileId)).Err()
+	tracing.End(span, err)
 	return err
 }
 
+// maxSwipeHistory is how many of the users last shown to a user are kept
+// for rewinding.
+const maxSwipeHistory = 100
+
+// swipeHistoryKey lists the users shown to a profile, most recent first.
+func swipeHistoryKey(profileId string) string {
+	return fmt.Sprintf("swipe_history:%s", profileId)
+}
+
+// rewindCountKey counts the rewinds of a user on the UTC day of t.
+func rewindCountKey(userId string, t time.Time) string {
+	return fmt.Sprintf("rewinds:%s:%s", userId, t.UTC().Format(time.DateOnly))
+}
+
+// RewindLastSwipe takes back the user HomePage showed last, so they can be
+// recommended again. It is limited to the daily rewinds of the user's plan,
+// counted per UTC day. Users the viewer liked are dropped from the history
+// and the user shown before them is rewound instead, since a like has
+// already been sent.
+func (user *UserService) RewindLastSwipe(ctx context.Context, req *pb.GetUserById) (*pb.RewindResponse, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.Id)
+	entitlements, err := user.repo(ctx).GetEntitlements(req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching entitlements", "error", err)
+		return nil, err
+	}
+	if entitlements.PlanId == "" {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if entitlements.DailyRewinds <= 0 {
+		return nil, status.Error(codes.PermissionDenied, "rewinds are not included in your plan")
+	}
+	profile, err := user.repo(ctx).GetProfileIdByUserId(req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching profile ID by user ID", "error", err)
+		return nil, err
+	}
+	rdb := user.redis.WithContext(ctx)
+
+	// The rewind is counted first and given back when nothing is rewound,
+	// so concurrent rewinds cannot exceed the limit.
+	countKey := rewindCountKey(req.Id, time.Now())
+	redisCtx, span := tracing.Start(ctx, "redis.Incr", attribute.String("redis.key", countKey))
+	used, err := user.redis.WithContext(redisCtx).Incr(countKey).Result()
+	if err == nil {
+		err = user.redis.WithContext(redisCtx).Expire(countKey, 48*time.Hour).Err()
+	}
+	tracing.End(span, err)
+	if err != nil {
+		logger.ErrorContext(ctx, "error counting rewinds", "error", err)
+		return nil, err
+	}
+	giveBack := func() {
+		if err := rdb.Decr(countKey).Err(); err != nil {
+			logger.WarnContext(ctx, "error giving back unused rewind", "error", err)
+		}
+	}
+	if used > int64(entitlements.DailyRewinds) {
+		giveBack()
+		return nil, status.Error(codes.ResourceExhausted, "no rewinds left today")
+	}
+
+	historyKey := swipeHistoryKey(profile)
+	var last string
+	// The history holds at most maxSwipeHistory users, which bounds the
+	// liked users skipped.
+	for skipped := 0; ; skipped++ {
+		if skipped == maxSwipeHistory {
+			giveBack()
+			return nil, status.Error(codes.FailedPrecondition, "nothing to rewind")
+		}
+		last, err = rdb.LPop(historyKey).Result()
+		if err == redis.Nil {
+			giveBack()
+			return nil, status.Error(codes.FailedPrecondition, "nothing to rewind")
+		}
+		if err != nil {
+			giveBack()
+			logger.ErrorContext(ctx, "error reading swipe history", "error", err)
+			return nil, err
+		}
+		liked, err := user.repo(ctx).HasLiked(req.Id, last)
+		if err != nil {
+			giveBack()
+			if pushErr := rdb.LPush(historyKey, last).Err(); pushErr != nil {
+				logger.WarnContext(ctx, "error restoring swipe history", "error", pushErr)
+			}
+			logger.ErrorContext(ctx, "error checking like", "error", err)
+			return nil, err
+		}
+		if !liked {
+			break
+		}
+	}
+	if err := rdb.ZRem(seenUsersKey(profile), last).Err(); err != nil {
+		logger.ErrorContext(ctx, "error removing rewound user from displayed users", "error", err)
+		return nil, err
+	}
+	logger.InfoContext(ctx, "swipe rewound", "rewound_user_id", last)
+	return &pb.RewindResponse{
+		RewoundUserId: last,
+		RewindsLeft:   int32(int64(entitlements.DailyRewinds) - used),
+	}, nil
+}
+
 func (user *UserService) GetUserData(ctx context.Context, req *pb.GetUserById) (*pb.UserDataResponse, error) {
-	userData, err := user.adapters.GetUserById(req.Id)
+	logger := logging.FromContext(ctx)
+	userData, err := user.repo(ctx).GetUserById(req.Id)
 	if err != nil {
-		logger.Error("error fetching userData", "user_id", req.Id, "error", err)
+		logger.ErrorContext(ctx, "error fetching userData", "user_id", req.Id, "error", err)
 		return nil, err
 	}
 	res := &pb.UserDataResponse{
@@ -882,17 +2122,389 @@ func (user *UserService) GetUserData(ctx context.Context, req *pb.GetUserById) (
 	return res, nil
 }
 
+// DecrementLikeCount uses up one of the user's daily likes. Likes of users
+// whose plan has entities.UnlimitedLikes are not counted.
 func (user *UserService) DecrementLikeCount(ctx context.Context, req *pb.GetUserById) (*pb.NoArg, error) {
-	err := user.adapters.DecrementLikeCount(req.Id)
+	logger := logging.FromContext(ctx).With("user_id", req.Id)
+	entitlements, err := user.repo(ctx).GetEntitlements(req.Id)
 	if err != nil {
+		logger.ErrorContext(ctx, "error fetching entitlements", "error", err)
 		return nil, err
 	}
-	return nil, nil
+	if entitlements.PlanId == "" {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if entitlements.DailyLikes != entities.UnlimitedLikes {
+		err = user.repo(ctx).DecrementLikeCount(req.Id)
+		if errors.Is(err, adapters.ErrNoLikesLeft) {
+			logger.WarnContext(ctx, "no likes left today")
+			return nil, status.Error(codes.ResourceExhausted, "no likes left today")
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "error decrementing like count", "error", err)
+			return nil, err
+		}
+	}
+	metrics.LikesConsumed.Inc()
+	return &pb.NoArg{}, nil
+}
+
+// RecordLike keeps a copy of a like made through the match service, so
+// incognito users can be recommended to the users they liked. Likes made
+// before the match service called it are brought in with ImportLikes.
+func (user *UserService) RecordLike(ctx context.Context, req *pb.UserLikeRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	err := user.repo(ctx).RecordLike(req.UserId, req.LikedId)
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		logger.WarnContext(ctx, "like between unknown users", "user_id", req.UserId, "liked_id", req.LikedId)
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "error recording like", "user_id", req.UserId, "liked_id", req.LikedId, "error", err)
+		return nil, err
+	}
+	if err := user.moveSeenUser(ctx, req.UserId, req.LikedId, true); err != nil {
+		logger.WarnContext(ctx, "error moving liked user out of seen users", "user_id", req.UserId, "liked_id", req.LikedId, "error", err)
+	}
+	return &pb.NoArg{}, nil
+}
+
+func (user *UserService) RemoveLike(ctx context.Context, req *pb.UserLikeRequest) (*pb.NoArg, error) {
+	logger := logging.FromContext(ctx)
+	if err := user.repo(ctx).RemoveLike(req.UserId, req.LikedId); err != nil {
+		logger.ErrorContext(ctx, "error removing like", "user_id", req.UserId, "liked_id", req.LikedId, "error", err)
+		return nil, err
+	}
+	if err := user.moveSeenUser(ctx, req.UserId, req.LikedId, false); err != nil {
+		logger.WarnContext(ctx, "error marking unliked user as seen", "user_id", req.UserId, "liked_id", req.LikedId, "error", err)
+	}
+	return &pb.NoArg{}, nil
+}
+
+// likeImportBatch is how many likes ImportLikes stores per transaction.
+const likeImportBatch = 500
+
+// ImportLikes stores the likes the match service kept before it recorded
+// them here, so incognito users are recommended to the users they already
+// liked. It can be run again; likes that are already recorded are skipped.
+func (user *UserService) ImportLikes(srv pb.UserService_ImportLikesServer) error {
+	ctx := srv.Context()
+	logger := logging.FromContext(ctx)
+	var received, imported int
+	batch := make([]entities.UserLike, 0, likeImportBatch)
+	flush := func() error {
+		n, err := user.repo(ctx).ImportLikes(batch)
+		if err != nil {
+			logger.ErrorContext(ctx, "error importing likes", "imported", imported, "error", err)
+			return err
+		}
+		imported += n
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "error receiving likes", "imported", imported, "error", err)
+			return err
+		}
+		like, err := importedLike(req)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		batch = append(batch, like)
+		received++
+		if len(batch) == likeImportBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	logger.InfoContext(ctx, "likes imported", "imported", imported, "skipped", received-imported)
+	return srv.SendAndClose(&pb.ImportLikesResponse{Imported: int32(imported), Skipped: int32(received - imported)})
+}
+
+// importedLike converts a like sent to ImportLikes. Likes without a time
+// are taken as made now.
+func importedLike(req *pb.UserLikeRequest) (entities.UserLike, error) {
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return entities.UserLike{}, fmt.Errorf("invalid user id %q", req.UserId)
+	}
+	likedId, err := uuid.Parse(req.LikedId)
+	if err != nil {
+		return entities.UserLike{}, fmt.Errorf("invalid liked id %q", req.LikedId)
+	}
+	likedAt := time.Now()
+	if req.LikedAt != "" {
+		if likedAt, err = time.Parse(time.RFC3339, req.LikedAt); err != nil {
+			return entities.UserLike{}, fmt.Errorf("invalid liked at %q", req.LikedAt)
+		}
+	}
+	return entities.UserLike{UserId: userId, LikedId: likedId, CreatedAt: likedAt}, nil
+}
+
+// moveSeenUser keeps liked users out of the seen users of userId, since
+// they are recycled by the like instead. A user that is no longer liked
+// is seen as of now, and recycled like a pass.
+func (user *UserService) moveSeenUser(ctx context.Context, userId, seenId string, liked bool) error {
+	if user.redis == nil {
+		return nil
+	}
+	profile, err := user.repo(ctx).GetProfileIdByUserId(userId)
+	if err != nil || profile == "" {
+		return err
+	}
+	seenKey := seenUsersKey(profile)
+	ctx, span := tracing.Start(ctx, "redis.ZAdd", attribute.String("redis.key", seenKey))
+	rdb := user.redis.WithContext(ctx)
+	if liked {
+		err = rdb.ZRem(seenKey, seenId).Err()
+	} else {
+		_, err = rdb.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.ZAdd(seenKey, redis.Z{Score: float64(time.Now().Unix()), Member: seenId})
+			pipe.Expire(seenKey, user.seenRecycle)
+			user.indexSeenBy(pipe, seenId, profile)
+			return nil
+		})
+	}
+	tracing.End(span, err)
+	return err
 }
 
+// UpdateSubscription starts a subscription to req.PlanId, or ends the
+// active subscription at once when req.Subscription is false. Callers that
+// only set the flag get the Plus plan for DefaultSubscriptionPeriod. The
+// change goes through the subscription ledger as an event of its own that
+// occurred at req.OccurredAt, or now when it is unset, so it fails with
+// FailedPrecondition instead of overriding a newer event.
 func (user *UserService) UpdateSubscription(ctx context.Context, req *pb.UpdateSubscriptionRequest) (*pb.NoArg, error) {
-	if err := user.adapters.UpdateSubscription(req.UserId, req.Subscription); err != nil {
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	now := time.Now()
+	occurredAt := now
+	if req.OccurredAt != "" {
+		if occurredAt, err = time.Parse(time.RFC3339, req.OccurredAt); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "occurred_at must be an RFC 3339 time")
+		}
+	}
+	event := entities.SubscriptionEvent{
+		Id:         "manual:" + uuid.NewString(),
+		UserId:     userId,
+		Type:       entities.SubscriptionEventCancelled,
+		PaymentRef: req.PaymentRef,
+		OccurredAt: occurredAt.Truncate(time.Microsecond),
+		ReceivedAt: now,
+	}
+	if req.Subscription {
+		event.Type = entities.SubscriptionEventActivated
+		event.PlanId = req.PlanId
+		if event.PlanId == "" {
+			event.PlanId = entities.PlanPlus
+		}
+		if event.PlanId == entities.PlanFree {
+			return nil, status.Error(codes.InvalidArgument, "the free plan needs no subscription")
+		}
+		expiresAt := event.OccurredAt.Add(DefaultSubscriptionPeriod)
+		if req.ExpiresAt != "" {
+			if expiresAt, err = time.Parse(time.RFC3339, req.ExpiresAt); err != nil {
+				return nil, status.Error(codes.InvalidArgument, "expires_at must be an RFC 3339 time")
+			}
+		}
+		event.ExpiresAt = &expiresAt
+	}
+	outcome, err := user.applySubscriptionEvent(ctx, event)
+	if err != nil {
 		return nil, err
 	}
-	return nil, nil
+	if outcome == entities.SubscriptionEventStale {
+		return nil, status.Error(codes.FailedPrecondition, "a newer subscription event has been applied")
+	}
+	return &pb.NoArg{}, nil
+}
+
+// subscriptionEventTypes names event types as the ledger stores them.
+var subscriptionEventTypes = map[pb.SubscriptionEventType]string{
+	pb.SubscriptionEventType_ACTIVATED: entities.SubscriptionEventActivated,
+	pb.SubscriptionEventType_RENEWED:   entities.SubscriptionEventRenewed,
+	pb.SubscriptionEventType_CANCELLED: entities.SubscriptionEventCancelled,
+	pb.SubscriptionEventType_REFUNDED:  entities.SubscriptionEventRefunded,
+}
+
+var subscriptionEventOutcomes = map[string]pb.SubscriptionEventOutcome{
+	entities.SubscriptionEventApplied:   pb.SubscriptionEventOutcome_APPLIED,
+	entities.SubscriptionEventStale:     pb.SubscriptionEventOutcome_STALE,
+	entities.SubscriptionEventDuplicate: pb.SubscriptionEventOutcome_DUPLICATE,
+}
+
+// ApplySubscriptionEvent applies an event from the payment service. Events
+// may be delivered more than once and out of order: a replayed event is
+// reported as a duplicate, and an event older than one already applied is
+// only recorded, so the newest event always decides the subscription.
+func (user *UserService) ApplySubscriptionEvent(ctx context.Context, req *pb.SubscriptionEventRequest) (*pb.SubscriptionEventResponse, error) {
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	occurredAt, err := time.Parse(time.RFC3339, req.OccurredAt)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "occurred_at must be an RFC 3339 time")
+	}
+	event := entities.SubscriptionEvent{
+		Id:         req.EventId,
+		UserId:     userId,
+		Type:       subscriptionEventTypes[req.Type],
+		PlanId:     req.PlanId,
+		PaymentRef: req.PaymentRef,
+		// Postgres keeps microseconds; truncating keeps the ordering of
+		// events the same before and after they are stored.
+		OccurredAt: occurredAt.Truncate(time.Microsecond),
+		ReceivedAt: time.Now(),
+	}
+	if event.Type == "" {
+		return nil, status.Error(codes.InvalidArgument, "unknown event type")
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be an RFC 3339 time")
+		}
+		event.ExpiresAt = &expiresAt
+	}
+	outcome, err := user.applySubscriptionEvent(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SubscriptionEventResponse{
+		EventId: req.EventId,
+		Outcome: subscriptionEventOutcomes[outcome],
+	}, nil
+}
+
+// applySubscriptionEvent adds event to the ledger, applies it unless a newer
+// event has been, and returns its outcome.
+func (user *UserService) applySubscriptionEvent(ctx context.Context, event entities.SubscriptionEvent) (string, error) {
+	logger := logging.FromContext(ctx).With("user_id", event.UserId.String(), "event_id", event.Id)
+	outcome, err := user.repo(ctx).ApplySubscriptionEvent(event, subscriptionFromEvent(event, event.ReceivedAt))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.WarnContext(ctx, "subscription event for unknown user")
+		return "", status.Error(codes.NotFound, "user not found")
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		logger.WarnContext(ctx, "subscription event for unknown plan", "plan_id", event.PlanId)
+		return "", status.Error(codes.NotFound, "plan not found")
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "error applying subscription event", "error", err)
+		return "", err
+	}
+	logger.InfoContext(ctx, "subscription event received", "type", event.Type, "occurred_at", event.OccurredAt, "outcome", outcome)
+	return outcome, nil
+}
+
+// subscriptionFromEvent returns the subscription an event leaves its user
+// with at now, or nil when it leaves them on the free plan. A cancelled
+// subscription keeps its plan until the end of the period paid for, while
+// a refund ends it at once. The result depends only on the event and now,
+// so applying only the newest event gives the same state as applying them
+// all in order at the same time.
+func subscriptionFromEvent(event entities.SubscriptionEvent, now time.Time) *entities.Subscription {
+	if event.Type == entities.SubscriptionEventRefunded || event.ExpiresAt == nil || !event.ExpiresAt.After(now) {
+		return nil
+	}
+	return &entities.Subscription{
+		Id:         uuid.New(),
+		UserId:     event.UserId,
+		PlanId:     event.PlanId,
+		Status:     entities.SubscriptionActive,
+		StartedAt:  event.OccurredAt,
+		ExpiresAt:  *event.ExpiresAt,
+		PaymentRef: event.PaymentRef,
+	}
+}
+
+func (user *UserService) GetAllPlans(e *pb.NoArg, srv pb.UserService_GetAllPlansServer) error {
+	logger := logging.FromContext(srv.Context())
+	plans, err := user.repo(srv.Context()).GetPlans()
+	if err != nil {
+		logger.ErrorContext(srv.Context(), "error fetching plans", "error", err)
+		return err
+	}
+	for _, plan := range plans {
+		if err := srv.Send(planResponse(plan)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetEntitlements returns the plan a user is on and what it allows them.
+func (user *UserService) GetEntitlements(ctx context.Context, req *pb.GetUserById) (*pb.EntitlementsResponse, error) {
+	logger := logging.FromContext(ctx).With("user_id", req.Id)
+	entitlements, err := user.repo(ctx).GetEntitlements(req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "error fetching entitlements", "error", err)
+		return nil, err
+	}
+	if entitlements.PlanId == "" {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	res := &pb.EntitlementsResponse{
+		UserId: req.Id,
+		Plan: planResponse(entities.Plan{
+			Id:              entitlements.PlanId,
+			Name:            entitlements.PlanName,
+			DailyLikes:      entitlements.DailyLikes,
+			DailySuperlikes: entitlements.DailySuperlikes,
+			DailyRewinds:    entitlements.DailyRewinds,
+			SeeWhoLikedYou:  entitlements.SeeWhoLikedYou,
+		}),
+	}
+	if entitlements.ExpiresAt != nil {
+		res.ExpiresAt = entitlements.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return res, nil
+}
+
+func planResponse(plan entities.Plan) *pb.PlanResponse {
+	return &pb.PlanResponse{
+		Id:              plan.Id,
+		Name:            plan.Name,
+		DailyLikes:      int32(plan.DailyLikes),
+		DailySuperlikes: int32(plan.DailySuperlikes),
+		DailyRewinds:    int32(plan.DailyRewinds),
+		SeeWhoLikedYou:  plan.SeeWhoLikedYou,
+	}
+}
+
+// ResetLikeQuotas gives every user the daily likes of their plan and
+// returns how many users were reset.
+func (user *UserService) ResetLikeQuotas(ctx context.Context) (int64, error) {
+	n, err := user.repo(ctx).ResetLikeQuotas()
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "error resetting like quotas", "error", err)
+		return 0, err
+	}
+	return n, nil
+}
+
+// ExpireSubscriptions ends subscriptions whose period is over and returns
+// how many ended.
+func (user *UserService) ExpireSubscriptions(ctx context.Context) (int64, error) {
+	n, err := user.repo(ctx).ExpireSubscriptions()
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "error expiring subscriptions", "error", err)
+		return 0, err
+	}
+	return n, nil
 }
@@ -5,6 +5,8 @@
 package mock_usecases
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	pb "github.com/akshaybt001/DatingApp_proto_files/pb"
@@ -34,17 +36,46 @@ func (m *MockUsecases) EXPECT() *MockUsecasesMockRecorder {
 	return m.recorder
 }
 
+// DeleteImages mocks base method.
+func (m *MockUsecases) DeleteImages(ctx context.Context, imageURLs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImages", ctx, imageURLs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImages indicates an expected call of DeleteImages.
+func (mr *MockUsecasesMockRecorder) DeleteImages(ctx, imageURLs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImages", reflect.TypeOf((*MockUsecases)(nil).DeleteImages), ctx, imageURLs)
+}
+
 // UploadImage mocks base method.
-func (m *MockUsecases) UploadImage(arg0 *pb.UserImageRequest, arg1 string) (string, error) {
+func (m *MockUsecases) UploadImage(arg0 context.Context, arg1 *pb.UserImageRequest, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadImage", arg0, arg1)
+	ret := m.ctrl.Call(m, "UploadImage", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UploadImage indicates an expected call of UploadImage.
-func (mr *MockUsecasesMockRecorder) UploadImage(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsecasesMockRecorder) UploadImage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockUsecases)(nil).UploadImage), arg0, arg1, arg2)
+}
+
+// UploadImageStream mocks base method.
+func (m *MockUsecases) UploadImageStream(ctx context.Context, objectName, contentType string, image io.Reader, profileId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImageStream", ctx, objectName, contentType, image, profileId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImageStream indicates an expected call of UploadImageStream.
+func (mr *MockUsecasesMockRecorder) UploadImageStream(ctx, objectName, contentType, image, profileId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockUsecases)(nil).UploadImage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImageStream", reflect.TypeOf((*MockUsecases)(nil).UploadImageStream), ctx, objectName, contentType, image, profileId)
 }
@@ -3,59 +3,137 @@ package usecases
 import (
 	"bytes"
 	"context"
-	"log"
-	"os"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/akshaybt001/DatingApp_UserService/config"
 	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	"github.com/akshaybt001/DatingApp_UserService/logging"
+	"github.com/akshaybt001/DatingApp_UserService/tracing"
 	"github.com/akshaybt001/DatingApp_proto_files/pb"
-	"github.com/go-redis/redis"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type UserUseCase struct {
 	userAdapter adapters.AdapterInterface
+	minioClient *minio.Client
+	bucket      string
 }
 
-func NewUserUseCase(useradapter adapters.AdapterInterface) *UserUseCase {
+func NewUserUseCase(useradapter adapters.AdapterInterface, cfg config.Minio) (*UserUseCase, error) {
+	minioClient, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &UserUseCase{
 		userAdapter: useradapter,
-	}
+		minioClient: minioClient,
+		bucket:      cfg.Bucket,
+	}, nil
 }
 
-var redisClient *redis.Client
-
-func init() {
-	redisClient = redis.NewClient(&redis.Options{
-		Addr:     "localhost:6379",
-		Password: "",
-		DB:       0,
-	})
+// CheckBucket reports an error unless the configured bucket exists.
+func (user *UserUseCase) CheckBucket(ctx context.Context) error {
+	exists, err := user.minioClient.BucketExists(ctx, user.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", user.bucket)
+	}
+	return nil
 }
 
-func (user *UserUseCase) UploadImage(req *pb.UserImageRequest, profileId string) (string, error) {
-	minioClient, err := minio.New(os.Getenv("MINIO_ENDPOINT"), &minio.Options{
-		Creds:  credentials.NewStaticV4(os.Getenv("MINIO_ACCESSKEY"), os.Getenv("MINIO_SECRETKEY"), ""),
-		Secure: false,
-	})
+func (user *UserUseCase) UploadImage(ctx context.Context, req *pb.UserImageRequest, profileId string) (string, error) {
+	objectName := "images/" + req.ObjectName
+	contentType := `image/jpeg`
+	putCtx, span := tracing.Start(ctx, "minio.PutObject", attribute.String("minio.object", objectName))
+	n, err := user.minioClient.PutObject(putCtx, user.bucket, objectName, bytes.NewReader(req.ImageData), int64(len(req.ImageData)), minio.PutObjectOptions{ContentType: contentType})
+	tracing.End(span, err)
 	if err != nil {
-		log.Print("error while initialising minio", err)
+		logging.FromContext(ctx).ErrorContext(ctx, "error while uploading to minio", "object", objectName, "error", err)
 		return "", err
 	}
-	objectName := "images/" + req.ObjectName
-	contentType := `image/jpeg`
-	n, err := minioClient.PutObject(context.Background(), os.Getenv("BUCKET_NAME"), objectName, bytes.NewReader(req.ImageData), int64(len(req.ImageData)), minio.PutObjectOptions{ContentType: contentType})
+	logging.FromContext(ctx).InfoContext(ctx, "successfully uploaded image", "object", objectName, "size", n.Size)
+	return user.saveProfileImage(ctx, objectName, profileId)
+}
+
+// streamPartSize bounds the buffer minio allocates per part when the
+// object size is not known up front.
+const streamPartSize = 5 << 20
+
+// UploadImageStream copies image into the bucket as it is read. The size is
+// passed as unknown so minio keeps reading until EOF; an error returned by
+// image (oversized upload, checksum mismatch) aborts the upload before the
+// object is committed.
+func (user *UserUseCase) UploadImageStream(ctx context.Context, objectName, contentType string, image io.Reader, profileId string) (string, error) {
+	objectName = "images/" + objectName
+	if contentType == "" {
+		contentType = `image/jpeg`
+	}
+	putCtx, span := tracing.Start(ctx, "minio.PutObject", attribute.String("minio.object", objectName))
+	n, err := user.minioClient.PutObject(putCtx, user.bucket, objectName, image, -1, minio.PutObjectOptions{ContentType: contentType, PartSize: streamPartSize})
+	tracing.End(span, err)
 	if err != nil {
-		log.Println("error while uploading to minio", err)
+		logging.FromContext(ctx).ErrorContext(ctx, "error while streaming to minio", "object", objectName, "error", err)
 		return "", err
 	}
-	log.Printf("Successfully uploaded %s of size %v\n", objectName, n)
-	presignedURL, err := minioClient.PresignedGetObject(context.Background(), os.Getenv("BUCKET_NAME"), objectName, time.Second*24*60*60, nil)
+	logging.FromContext(ctx).InfoContext(ctx, "successfully uploaded image", "object", objectName, "size", n.Size)
+	return user.saveProfileImage(ctx, objectName, profileId)
+}
+
+func (user *UserUseCase) saveProfileImage(ctx context.Context, objectName, profileId string) (string, error) {
+	presignCtx, span := tracing.Start(ctx, "minio.PresignedGetObject", attribute.String("minio.object", objectName))
+	presignedURL, err := user.minioClient.PresignedGetObject(presignCtx, user.bucket, objectName, time.Second*24*60*60, nil)
+	tracing.End(span, err)
 	if err != nil {
-		log.Println("error while generating presigned URL", err)
+		logging.FromContext(ctx).ErrorContext(ctx, "error while generating presigned URL", "object", objectName, "error", err)
 		return "", err
 	}
-	url, err := user.userAdapter.UploadProfileImage(presignedURL.String(), profileId)
+	url, err := adapters.WithContext(user.userAdapter, ctx).UploadProfileImage(presignedURL.String(), profileId)
 	return url, err
 }
+
+// DeleteImages removes the objects behind stored image URLs. Objects that
+// are already gone are not an error, so a purge can be retried.
+func (user *UserUseCase) DeleteImages(ctx context.Context, imageURLs []string) error {
+	for _, imageURL := range imageURLs {
+		objectName, err := user.objectName(imageURL)
+		if err != nil {
+			return err
+		}
+		removeCtx, span := tracing.Start(ctx, "minio.RemoveObject", attribute.String("minio.object", objectName))
+		err = user.minioClient.RemoveObject(removeCtx, user.bucket, objectName, minio.RemoveObjectOptions{})
+		tracing.End(span, err)
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "error while removing from minio", "object", objectName, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// objectName returns the object a presigned URL points to. The bucket is
+// part of the path for path-style URLs and of the host otherwise.
+func (user *UserUseCase) objectName(imageURL string) (string, error) {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing image url: %w", err)
+	}
+	name := strings.TrimPrefix(u.Path, "/")
+	if !strings.HasPrefix(u.Host, user.bucket+".") {
+		name = strings.TrimPrefix(name, user.bucket+"/")
+	}
+	if name == "" {
+		return "", fmt.Errorf("image url %q names no object", imageURL)
+	}
+	return name, nil
+}
@@ -1,9 +1,16 @@
 package usecases
 
-import "github.com/akshaybt001/DatingApp_proto_files/pb"
+import (
+	"context"
+	"io"
+
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+)
 
 type Usecases interface {
-	UploadImage(*pb.UserImageRequest,string)(string,error)
+	UploadImage(context.Context, *pb.UserImageRequest, string) (string, error)
+	UploadImageStream(ctx context.Context, objectName, contentType string, image io.Reader, profileId string) (string, error)
+	DeleteImages(ctx context.Context, imageURLs []string) error
 	// UpdateDisplayedUserIds(userID string, displayedUserIds map[string]bool) error
 	// GetDisplayedUserIds(userID string) (map[string]bool, error) 
-}
\ No newline at end of file
+}
@@ -0,0 +1,494 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MinAge and MaxAge bound both a user's own age and the age range of their
+// preferences.
+const (
+	MinAge = 18
+	MaxAge = 120
+)
+
+const (
+	maxNameLen     = 50
+	maxEmailLen    = 254
+	maxPasswordLen = 72 // bcrypt ignores anything longer
+	maxPlaceLen    = 100
+	maxCatalogLen  = 50
+	maxObjectLen   = 255
+	maxDistanceKm  = 500
+	maxBioLen      = 500
+	maxPrompts     = 3
+	maxQuestionLen = 100
+	maxAnswerLen   = 300
+	minHeightCm    = 100
+	maxHeightCm    = 250
+)
+
+var (
+	e164     = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	checksum = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	locale   = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+	confirmationCode = regexp.MustCompile(`^[0-9]{6}$`)
+)
+
+// Violations collects every problem found in a request so the caller gets
+// them all at once.
+type Violations struct {
+	list []*errdetails.BadRequest_FieldViolation
+}
+
+func (v *Violations) Add(field, description string) {
+	v.list = append(v.list, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+// Err returns an InvalidArgument status carrying the violations as a
+// BadRequest detail, or nil when there are none.
+func (v *Violations) Err() error {
+	if len(v.list) == 0 {
+		return nil
+	}
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid request: %s %s", v.list[0].Field, v.list[0].Description))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v.list})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// Validate checks req against the rules for its message type. Messages
+// without rules are accepted.
+func Validate(req interface{}) error {
+	v := &Violations{}
+	switch r := req.(type) {
+	case *pb.UserSignupRequest:
+		email(v, "email", r.Email)
+		length(v, "name", r.Name, 1, maxNameLen)
+		length(v, "password", r.Password, 1, maxPasswordLen)
+		phone(v, "phone", r.Phone)
+	case *pb.LoginRequest:
+		length(v, "email", r.Email, 1, maxEmailLen)
+		length(v, "password", r.Password, 1, maxPasswordLen)
+	case *pb.GetUserById:
+		id(v, "id", r.Id)
+	case *pb.AddInterestRequest:
+		length(v, "interest", r.Interest, 1, maxCatalogLen)
+		optional(v, "category_id", r.CategoryId)
+	case *pb.InterestResponse:
+		positive(v, "id", r.Id)
+		length(v, "interest", r.Interest, 1, maxCatalogLen)
+		optional(v, "category_id", r.CategoryId)
+	case *pb.InterestFilter:
+		optional(v, "category_id", r.CategoryId)
+	case *pb.InterestCategoryRequest:
+		length(v, "name", r.Name, 1, maxCatalogLen)
+		if r.Position < 0 {
+			v.Add("position", "must not be negative")
+		}
+	case *pb.InterestCategoryResponse:
+		positive(v, "id", r.Id)
+		length(v, "name", r.Name, 1, maxCatalogLen)
+		if r.Position < 0 {
+			v.Add("position", "must not be negative")
+		}
+	case *pb.SetUserInterestsRequest:
+		id(v, "user_id", r.UserId)
+		for i, interest := range r.InterestIds {
+			positive(v, fmt.Sprintf("interest_ids[%d]", i), interest)
+		}
+	case *pb.AddGenderRequest:
+		length(v, "gender", r.Gender, 1, maxCatalogLen)
+	case *pb.GenderResponse:
+		positive(v, "id", r.Id)
+		length(v, "gender", r.Gender, 1, maxCatalogLen)
+	case *pb.DeleteInterestRequest:
+		positive(v, "interest_id", r.InterestId)
+		id(v, "user_id", r.UserId)
+	case *pb.UpdateGenderRequest:
+		positive(v, "gender_id", r.GenderId)
+		id(v, "user_id", r.UserId)
+	case *pb.GetInterestByIdRequest:
+		positive(v, "id", r.Id)
+	case *pb.DeleteCatalogRequest:
+		positive(v, "id", r.Id)
+	case *pb.MergeCatalogRequest:
+		positive(v, "source_id", r.SourceId)
+		positive(v, "target_id", r.TargetId)
+		if r.SourceId == r.TargetId {
+			v.Add("target_id", "must differ from source_id")
+		}
+	case *pb.TranslationRequest:
+		translation(v, r.Entity, r.EntityId, r.Locale)
+		length(v, "name", r.Name, 1, maxCatalogLen)
+	case *pb.TranslationKey:
+		translation(v, r.Entity, r.EntityId, r.Locale)
+	case *pb.AddAddressRequest:
+		address(v, r.Country, r.State, r.District, r.City)
+		id(v, "user_id", r.UserId)
+	case *pb.AddressResponse:
+		address(v, r.Country, r.State, r.District, r.City)
+		id(v, "user_id", r.UserId)
+	case *pb.PreferenceRequest:
+		preference(v, r.Minage, r.Maxage, r.Gender, r.Genders, r.Desirecity, r.MaxDistanceKm)
+		id(v, "user_id", r.UserId)
+	case *pb.PreferenceResponse:
+		preference(v, r.Minage, r.Maxage, r.Gender, r.Genders, r.Desirecity, r.MaxDistanceKm)
+		id(v, "user_id", r.UserId)
+	case *pb.UpdateProfileRequest:
+		id(v, "user_id", r.UserId)
+		if r.UpdateMask != nil && !r.UpdateMask.IsValid(&pb.ProfileDetails{}) {
+			v.Add("update_mask", "must only name fields of the profile")
+			break
+		}
+		profile(v, r.GetProfile(), helper.MaskPaths(r.UpdateMask, &pb.ProfileDetails{}))
+	case *pb.UpdateAccountRequest:
+		id(v, "user_id", r.UserId)
+		if r.UpdateMask != nil && !r.UpdateMask.IsValid(&pb.AccountDetails{}) {
+			v.Add("update_mask", "must only name fields of the account")
+			break
+		}
+		account(v, r.GetAccount(), helper.MaskPaths(r.UpdateMask, &pb.AccountDetails{}))
+		length(v, "current_password", r.CurrentPassword, 0, maxPasswordLen)
+	case *pb.ConfirmAccountChangeRequest:
+		id(v, "user_id", r.UserId)
+		switch r.Field {
+		case "email":
+			email(v, "value", r.Value)
+		case "phone":
+			phone(v, "value", r.Value)
+		default:
+			v.Add("field", "must be email or phone")
+		}
+		if !confirmationCode.MatchString(r.Code) {
+			v.Add("code", "must be six digits")
+		}
+	case *pb.AccountDeletionRequest:
+		id(v, "user_id", r.UserId)
+		length(v, "current_password", r.CurrentPassword, 1, maxPasswordLen)
+	case *pb.DiscoveryModeRequest:
+		id(v, "user_id", r.UserId)
+		if _, ok := pb.DiscoveryMode_name[int32(r.Mode)]; !ok {
+			v.Add("mode", "must be a known discovery mode")
+		}
+	case *pb.UserLikeRequest:
+		id(v, "user_id", r.UserId)
+		id(v, "liked_id", r.LikedId)
+		if r.UserId == r.LikedId {
+			v.Add("liked_id", "must differ from user_id")
+		}
+		if r.LikedAt != "" {
+			timestamp(v, "liked_at", r.LikedAt)
+		}
+	case *pb.UpdateLocationRequest:
+		id(v, "user_id", r.UserId)
+		if r.Latitude < -90 || r.Latitude > 90 {
+			v.Add("latitude", "must be between -90 and 90")
+		}
+		if r.Longitude < -180 || r.Longitude > 180 {
+			v.Add("longitude", "must be between -180 and 180")
+		}
+	case *pb.UserAgeRequest:
+		dob(v, "dob", r.Dob)
+		id(v, "user_id", r.UserId)
+	case *pb.UserImageRequest:
+		id(v, "user_id", r.UserId)
+		length(v, "object_name", r.ObjectName, 1, maxObjectLen)
+		if len(r.ImageData) == 0 {
+			v.Add("image_data", "must not be empty")
+		}
+	case *pb.UploadImageChunk:
+		if meta := r.GetMetadata(); meta != nil {
+			id(v, "metadata.user_id", meta.UserId)
+			length(v, "metadata.object_name", meta.ObjectName, 1, maxObjectLen)
+			if !checksum.MatchString(meta.Checksum) {
+				v.Add("metadata.checksum", "must be a hex encoded sha256 digest")
+			}
+			if meta.Size < 0 {
+				v.Add("metadata.size", "must not be negative")
+			}
+		}
+	case *pb.UpdateSubscriptionRequest:
+		id(v, "user_id", r.UserId)
+		if len(r.PlanId) > maxCatalogLen {
+			v.Add("plan_id", fmt.Sprintf("must be at most %d characters", maxCatalogLen))
+		}
+		if r.ExpiresAt != "" {
+			if expiresAt, ok := timestamp(v, "expires_at", r.ExpiresAt); ok && r.Subscription && !expiresAt.After(time.Now()) {
+				v.Add("expires_at", "must be in the future")
+			}
+		}
+		length(v, "payment_ref", r.PaymentRef, 0, maxObjectLen)
+		if r.OccurredAt != "" {
+			timestamp(v, "occurred_at", r.OccurredAt)
+		}
+	case *pb.SubscriptionEventRequest:
+		length(v, "event_id", r.EventId, 1, maxObjectLen)
+		id(v, "user_id", r.UserId)
+		timestamp(v, "occurred_at", r.OccurredAt)
+		switch r.Type {
+		case pb.SubscriptionEventType_ACTIVATED, pb.SubscriptionEventType_RENEWED:
+			length(v, "plan_id", r.PlanId, 1, maxCatalogLen)
+			timestamp(v, "expires_at", r.ExpiresAt)
+		case pb.SubscriptionEventType_CANCELLED:
+			// Without an expiry the subscription ends at once.
+			if r.ExpiresAt != "" {
+				length(v, "plan_id", r.PlanId, 1, maxCatalogLen)
+				timestamp(v, "expires_at", r.ExpiresAt)
+			}
+		case pb.SubscriptionEventType_REFUNDED:
+		default:
+			v.Add("type", "must be a known event type")
+		}
+		length(v, "payment_ref", r.PaymentRef, 0, maxObjectLen)
+	}
+	return v.Err()
+}
+
+func length(v *Violations, field, value string, min, max int) {
+	n := utf8.RuneCountInString(value)
+	switch {
+	case n < min && min == 1:
+		v.Add(field, "must not be empty")
+	case n < min:
+		v.Add(field, fmt.Sprintf("must be at least %d characters", min))
+	case n > max:
+		v.Add(field, fmt.Sprintf("must be at most %d characters", max))
+	}
+}
+
+func email(v *Violations, field, value string) {
+	if len(value) > maxEmailLen {
+		v.Add(field, fmt.Sprintf("must be at most %d characters", maxEmailLen))
+		return
+	}
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Address != value {
+		v.Add(field, "must be a valid email address")
+	}
+}
+
+func phone(v *Violations, field, value string) {
+	if !e164.MatchString(value) {
+		v.Add(field, "must be an E.164 phone number such as +919876543210")
+	}
+}
+
+func id(v *Violations, field, value string) {
+	if _, err := uuid.Parse(value); err != nil {
+		v.Add(field, "must be a valid UUID")
+	}
+}
+
+func positive(v *Violations, field string, value int32) {
+	if value <= 0 {
+		v.Add(field, "must be a positive id")
+	}
+}
+
+func translation(v *Violations, entity string, entityId int32, tag string) {
+	switch entity {
+	case entities.TranslationInterest, entities.TranslationInterestCategory, entities.TranslationGender:
+	default:
+		v.Add("entity", "must be one of interest, interest_category or gender")
+	}
+	positive(v, "entity_id", entityId)
+	if !locale.MatchString(helper.NormalizeLocale(tag)) {
+		v.Add("locale", "must be a language tag such as pt-BR")
+	}
+}
+
+// profile checks the fields of d named in paths.
+func profile(v *Violations, d *pb.ProfileDetails, paths []string) {
+	for _, path := range paths {
+		switch path {
+		case "name":
+			length(v, "profile.name", d.GetName(), 1, maxNameLen)
+		case "bio":
+			length(v, "profile.bio", d.GetBio(), 0, maxBioLen)
+		case "occupation":
+			length(v, "profile.occupation", d.GetOccupation(), 0, maxPlaceLen)
+		case "education":
+			length(v, "profile.education", d.GetEducation(), 0, maxPlaceLen)
+		case "heightCm":
+			if h := d.GetHeightCm(); h != 0 && (h < minHeightCm || h > maxHeightCm) {
+				v.Add("profile.height_cm", fmt.Sprintf("must be 0 or between %d and %d", minHeightCm, maxHeightCm))
+			}
+		case "drinking":
+			frequency(v, "profile.drinking", d.GetDrinking())
+		case "smoking":
+			frequency(v, "profile.smoking", d.GetSmoking())
+		case "exercise":
+			frequency(v, "profile.exercise", d.GetExercise())
+		case "prompts":
+			prompts := d.GetPrompts()
+			if len(prompts) > maxPrompts {
+				v.Add("profile.prompts", fmt.Sprintf("must have at most %d prompts", maxPrompts))
+			}
+			questions := make(map[string]bool)
+			for i, prompt := range prompts {
+				field := fmt.Sprintf("profile.prompts[%d]", i)
+				length(v, field+".question", prompt.GetQuestion(), 1, maxQuestionLen)
+				length(v, field+".answer", prompt.GetAnswer(), 1, maxAnswerLen)
+				question := strings.ToLower(strings.TrimSpace(prompt.GetQuestion()))
+				if questions[question] {
+					v.Add(field+".question", "must not repeat another prompt")
+				}
+				questions[question] = true
+			}
+		}
+	}
+}
+
+// account checks the fields of d named in paths.
+func account(v *Violations, d *pb.AccountDetails, paths []string) {
+	for _, path := range paths {
+		switch path {
+		case "name":
+			length(v, "account.name", d.GetName(), 1, maxNameLen)
+		case "email":
+			email(v, "account.email", d.GetEmail())
+		case "phone":
+			phone(v, "account.phone", d.GetPhone())
+		}
+	}
+}
+
+func frequency(v *Violations, field string, value pb.Frequency) {
+	if _, ok := pb.Frequency_name[int32(value)]; !ok {
+		v.Add(field, "must be a known frequency")
+	}
+}
+
+// optional checks an id where 0 means none.
+func optional(v *Violations, field string, value int32) {
+	if value < 0 {
+		v.Add(field, "must be a positive id or 0")
+	}
+}
+
+func address(v *Violations, country, state, district, city string) {
+	length(v, "country", country, 1, maxPlaceLen)
+	length(v, "state", state, 1, maxPlaceLen)
+	length(v, "district", district, 1, maxPlaceLen)
+	length(v, "city", city, 1, maxPlaceLen)
+}
+
+func preference(v *Violations, minAge, maxAge, gender int32, genders []int32, city string, distance int32) {
+	if minAge < MinAge || minAge > MaxAge {
+		v.Add("minage", fmt.Sprintf("must be between %d and %d", MinAge, MaxAge))
+	}
+	if maxAge < MinAge || maxAge > MaxAge {
+		v.Add("maxage", fmt.Sprintf("must be between %d and %d", MinAge, MaxAge))
+	}
+	if minAge > maxAge {
+		v.Add("minage", "must not be greater than maxage")
+	}
+	if len(genders) == 0 {
+		positive(v, "gender", gender)
+	}
+	for i, g := range genders {
+		positive(v, fmt.Sprintf("genders[%d]", i), g)
+	}
+	length(v, "desirecity", city, 1, maxPlaceLen)
+	if distance < 0 || distance > maxDistanceKm {
+		v.Add("max_distance_km", fmt.Sprintf("must be between 0 and %d", maxDistanceKm))
+	}
+}
+
+// timestamp parses an RFC 3339 time and reports whether it could.
+func timestamp(v *Violations, field, value string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		v.Add(field, "must be a time such as 2024-05-01T10:00:00Z")
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func dob(v *Violations, field, value string) {
+	date, err := helper.ParseDOB(value)
+	if err != nil {
+		v.Add(field, "must be a date such as 1990-04-23")
+		return
+	}
+	if date.After(time.Now()) {
+		v.Add(field, "must not be in the future")
+		return
+	}
+	age := helper.CalculateAge(date)
+	if age < MinAge {
+		v.Add(field, fmt.Sprintf("users must be at least %d years old", MinAge))
+	}
+	if age > MaxAge {
+		v.Add(field, fmt.Sprintf("must give an age of at most %d", MaxAge))
+	}
+}
+
+// Normalize rewrites the emails and phone numbers of req into the form they
+// are stored in. It is the one place where this happens, so handlers and
+// adapters can compare them as they are.
+func Normalize(req interface{}) {
+	switch r := req.(type) {
+	case *pb.UserSignupRequest:
+		r.Email = helper.NormalizeEmail(r.Email)
+		r.Phone = helper.NormalizePhone(r.Phone)
+	case *pb.LoginRequest:
+		r.Email = helper.NormalizeEmail(r.Email)
+	case *pb.UpdateAccountRequest:
+		if r.Account != nil {
+			r.Account.Email = helper.NormalizeEmail(r.Account.Email)
+			r.Account.Phone = helper.NormalizePhone(r.Account.Phone)
+		}
+	case *pb.ConfirmAccountChangeRequest:
+		switch r.Field {
+		case "email":
+			r.Value = helper.NormalizeEmail(r.Value)
+		case "phone":
+			r.Value = helper.NormalizePhone(r.Value)
+		}
+	}
+}
+
+// UnaryServerInterceptor normalizes requests and rejects those that fail
+// Validate with InvalidArgument before they reach the handler.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	Normalize(req)
+	if err := Validate(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor validates every message the handler receives.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatedStream{ss})
+}
+
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return Validate(m)
+}
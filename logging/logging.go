@@ -0,0 +1,188 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/config"
+	"github.com/akshaybt001/DatingApp_UserService/tracing"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is read from incoming metadata and echoed back in the
+// response headers; a new id is generated when the caller sent none.
+const RequestIDHeader = "x-request-id"
+
+// New builds the service logger: text or JSON output at the configured
+// level, with PII redacted and trace ids attached.
+func New(w io.Writer, cfg config.Logging) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.Level}
+	var handler slog.Handler
+	if cfg.Format == config.LogFormatJSON {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(tracing.LogHandler{Handler: RedactHandler{handler}})
+}
+
+type ctxKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the request logger stored in ctx, or the default
+// logger outside of a request.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// UnaryServerInterceptor attaches a logger carrying the request id, the
+// method and, when the request names one, the user id to the request
+// context, and logs the outcome of the call.
+func UnaryServerInterceptor(base *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		logger := requestLogger(ctx, base, info.FullMethod)
+		if id := userID(req); id != "" {
+			logger = logger.With("user_id", id)
+		}
+		ctx = WithLogger(ctx, logger)
+		start := time.Now()
+		res, err := handler(ctx, req)
+		logCall(ctx, logger, start, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor. Stream messages are read by the handler, so the
+// user id is left for the handler to add.
+func StreamServerInterceptor(base *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		logger := requestLogger(ss.Context(), base, info.FullMethod)
+		ctx := WithLogger(ss.Context(), logger)
+		start := time.Now()
+		err := handler(srv, &loggedStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, logger, start, err)
+		return err
+	}
+}
+
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+func requestLogger(ctx context.Context, base *slog.Logger, method string) *slog.Logger {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDHeader); len(ids) > 0 {
+			requestID = ids[0]
+		}
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+	return base.With("request_id", requestID, "method", method)
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, start time.Time, err error) {
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	logger.Log(ctx, level, "request finished", "code", status.Code(err).String(), "duration", time.Since(start))
+}
+
+func userID(req interface{}) string {
+	switch r := req.(type) {
+	case interface{ GetUserId() string }:
+		return r.GetUserId()
+	case interface{ GetId() string }:
+		return r.GetId()
+	}
+	return ""
+}
+
+// RedactHandler masks the values of attributes that hold personal data
+// before they reach the wrapped handler, including attributes added with
+// Logger.With and attributes nested in groups.
+type RedactHandler struct {
+	slog.Handler
+}
+
+func (h RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redact(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redact(a)
+	}
+	return RedactHandler{h.Handler.WithAttrs(redacted)}
+}
+
+func (h RedactHandler) WithGroup(name string) slog.Handler {
+	return RedactHandler{h.Handler.WithGroup(name)}
+}
+
+func redact(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, g := range group {
+			redacted[i] = redact(g)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	}
+	switch strings.ToLower(a.Key) {
+	case "email":
+		return slog.String(a.Key, MaskEmail(a.Value.String()))
+	case "phone":
+		return slog.String(a.Key, MaskPhone(a.Value.String()))
+	case "password":
+		return slog.String(a.Key, "[REDACTED]")
+	}
+	return a
+}
+
+// MaskEmail keeps the first character of the local part and the domain,
+// e.g. "a***@example.com".
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// MaskPhone keeps only the last two digits of a phone number.
+func MaskPhone(phone string) string {
+	if len(phone) <= 2 {
+		return "***"
+	}
+	return strings.Repeat("*", len(phone)-2) + phone[len(phone)-2:]
+}
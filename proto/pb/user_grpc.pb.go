@@ -0,0 +1,2587 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: user.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	UserService_UserSignup_FullMethodName                  = "/user.UserService/UserSignup"
+	UserService_UserLogin_FullMethodName                   = "/user.UserService/UserLogin"
+	UserService_AdminLogin_FullMethodName                  = "/user.UserService/AdminLogin"
+	UserService_CreateProfile_FullMethodName               = "/user.UserService/CreateProfile"
+	UserService_UpdateProfile_FullMethodName               = "/user.UserService/UpdateProfile"
+	UserService_GetProfile_FullMethodName                  = "/user.UserService/GetProfile"
+	UserService_UpdateAccount_FullMethodName               = "/user.UserService/UpdateAccount"
+	UserService_ConfirmAccountChange_FullMethodName        = "/user.UserService/ConfirmAccountChange"
+	UserService_RequestAccountDeletion_FullMethodName      = "/user.UserService/RequestAccountDeletion"
+	UserService_ExportMyData_FullMethodName                = "/user.UserService/ExportMyData"
+	UserService_SetDiscoveryMode_FullMethodName            = "/user.UserService/SetDiscoveryMode"
+	UserService_GetDiscoveryMode_FullMethodName            = "/user.UserService/GetDiscoveryMode"
+	UserService_GetUser_FullMethodName                     = "/user.UserService/GetUser"
+	UserService_AdminAddInterest_FullMethodName            = "/user.UserService/AdminAddInterest"
+	UserService_AdminDeleteInterest_FullMethodName         = "/user.UserService/AdminDeleteInterest"
+	UserService_AdminMergeInterests_FullMethodName         = "/user.UserService/AdminMergeInterests"
+	UserService_AdminUpdateInterest_FullMethodName         = "/user.UserService/AdminUpdateInterest"
+	UserService_GetAllInterest_FullMethodName              = "/user.UserService/GetAllInterest"
+	UserService_AdminAddInterestCategory_FullMethodName    = "/user.UserService/AdminAddInterestCategory"
+	UserService_AdminUpdateInterestCategory_FullMethodName = "/user.UserService/AdminUpdateInterestCategory"
+	UserService_AdminDeleteInterestCategory_FullMethodName = "/user.UserService/AdminDeleteInterestCategory"
+	UserService_GetAllInterestCategories_FullMethodName    = "/user.UserService/GetAllInterestCategories"
+	UserService_AddInterestUser_FullMethodName             = "/user.UserService/AddInterestUser"
+	UserService_DeleteInterestUser_FullMethodName          = "/user.UserService/DeleteInterestUser"
+	UserService_SetUserInterests_FullMethodName            = "/user.UserService/SetUserInterests"
+	UserService_GetInterestById_FullMethodName             = "/user.UserService/GetInterestById"
+	UserService_GetAllInterestsUser_FullMethodName         = "/user.UserService/GetAllInterestsUser"
+	UserService_UserAddAge_FullMethodName                  = "/user.UserService/UserAddAge"
+	UserService_UserGetAge_FullMethodName                  = "/user.UserService/UserGetAge"
+	UserService_UserAddAddress_FullMethodName              = "/user.UserService/UserAddAddress"
+	UserService_UserEditAddress_FullMethodName             = "/user.UserService/UserEditAddress"
+	UserService_UserGetAddress_FullMethodName              = "/user.UserService/UserGetAddress"
+	UserService_UpdateLocation_FullMethodName              = "/user.UserService/UpdateLocation"
+	UserService_AdminAddGender_FullMethodName              = "/user.UserService/AdminAddGender"
+	UserService_AdminUpdateGender_FullMethodName           = "/user.UserService/AdminUpdateGender"
+	UserService_AdminDeleteGender_FullMethodName           = "/user.UserService/AdminDeleteGender"
+	UserService_AdminMergeGenders_FullMethodName           = "/user.UserService/AdminMergeGenders"
+	UserService_GetAllGender_FullMethodName                = "/user.UserService/GetAllGender"
+	UserService_AdminUpsertTranslation_FullMethodName      = "/user.UserService/AdminUpsertTranslation"
+	UserService_AdminDeleteTranslation_FullMethodName      = "/user.UserService/AdminDeleteTranslation"
+	UserService_AddGenderUser_FullMethodName               = "/user.UserService/AddGenderUser"
+	UserService_GetAllGenderUser_FullMethodName            = "/user.UserService/GetAllGenderUser"
+	UserService_RemoveGenderUser_FullMethodName            = "/user.UserService/RemoveGenderUser"
+	UserService_UserAddPreference_FullMethodName           = "/user.UserService/UserAddPreference"
+	UserService_UserEditPreference_FullMethodName          = "/user.UserService/UserEditPreference"
+	UserService_GetAllPreference_FullMethodName            = "/user.UserService/GetAllPreference"
+	UserService_UserUploadProfileImage_FullMethodName      = "/user.UserService/UserUploadProfileImage"
+	UserService_UploadProfileImageStream_FullMethodName    = "/user.UserService/UploadProfileImageStream"
+	UserService_UserGetProfilePic_FullMethodName           = "/user.UserService/UserGetProfilePic"
+	UserService_HomePage_FullMethodName                    = "/user.UserService/HomePage"
+	UserService_RewindLastSwipe_FullMethodName             = "/user.UserService/RewindLastSwipe"
+	UserService_IsUserExist_FullMethodName                 = "/user.UserService/IsUserExist"
+	UserService_GetUserData_FullMethodName                 = "/user.UserService/GetUserData"
+	UserService_DecrementLikeCount_FullMethodName          = "/user.UserService/DecrementLikeCount"
+	UserService_RecordLike_FullMethodName                  = "/user.UserService/RecordLike"
+	UserService_RemoveLike_FullMethodName                  = "/user.UserService/RemoveLike"
+	UserService_ImportLikes_FullMethodName                 = "/user.UserService/ImportLikes"
+	UserService_UpdateSubscription_FullMethodName          = "/user.UserService/UpdateSubscription"
+	UserService_ApplySubscriptionEvent_FullMethodName      = "/user.UserService/ApplySubscriptionEvent"
+	UserService_GetAllPlans_FullMethodName                 = "/user.UserService/GetAllPlans"
+	UserService_GetEntitlements_FullMethodName             = "/user.UserService/GetEntitlements"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserServiceClient interface {
+	UserSignup(ctx context.Context, in *UserSignupRequest, opts ...grpc.CallOption) (*UserSignupResponse, error)
+	UserLogin(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*UserSignupResponse, error)
+	AdminLogin(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*UserSignupResponse, error)
+	CreateProfile(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*NoArg, error)
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
+	GetProfile(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*ProfileResponse, error)
+	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	ConfirmAccountChange(ctx context.Context, in *ConfirmAccountChangeRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	RequestAccountDeletion(ctx context.Context, in *AccountDeletionRequest, opts ...grpc.CallOption) (*AccountDeletionResponse, error)
+	ExportMyData(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (UserService_ExportMyDataClient, error)
+	SetDiscoveryMode(ctx context.Context, in *DiscoveryModeRequest, opts ...grpc.CallOption) (*DiscoveryModeResponse, error)
+	GetDiscoveryMode(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*DiscoveryModeResponse, error)
+	GetUser(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserSignupResponse, error)
+	AdminAddInterest(ctx context.Context, in *AddInterestRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminDeleteInterest(ctx context.Context, in *DeleteCatalogRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminMergeInterests(ctx context.Context, in *MergeCatalogRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminUpdateInterest(ctx context.Context, in *InterestResponse, opts ...grpc.CallOption) (*NoArg, error)
+	GetAllInterest(ctx context.Context, in *InterestFilter, opts ...grpc.CallOption) (UserService_GetAllInterestClient, error)
+	AdminAddInterestCategory(ctx context.Context, in *InterestCategoryRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminUpdateInterestCategory(ctx context.Context, in *InterestCategoryResponse, opts ...grpc.CallOption) (*NoArg, error)
+	AdminDeleteInterestCategory(ctx context.Context, in *DeleteCatalogRequest, opts ...grpc.CallOption) (*NoArg, error)
+	GetAllInterestCategories(ctx context.Context, in *NoArg, opts ...grpc.CallOption) (UserService_GetAllInterestCategoriesClient, error)
+	AddInterestUser(ctx context.Context, in *DeleteInterestRequest, opts ...grpc.CallOption) (*NoArg, error)
+	DeleteInterestUser(ctx context.Context, in *DeleteInterestRequest, opts ...grpc.CallOption) (*NoArg, error)
+	SetUserInterests(ctx context.Context, in *SetUserInterestsRequest, opts ...grpc.CallOption) (*NoArg, error)
+	GetInterestById(ctx context.Context, in *GetInterestByIdRequest, opts ...grpc.CallOption) (*InterestResponse, error)
+	GetAllInterestsUser(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (UserService_GetAllInterestsUserClient, error)
+	UserAddAge(ctx context.Context, in *UserAgeRequest, opts ...grpc.CallOption) (*NoArg, error)
+	UserGetAge(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserAgeResponse, error)
+	UserAddAddress(ctx context.Context, in *AddAddressRequest, opts ...grpc.CallOption) (*NoArg, error)
+	UserEditAddress(ctx context.Context, in *AddressResponse, opts ...grpc.CallOption) (*NoArg, error)
+	UserGetAddress(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*AddressResponse, error)
+	UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminAddGender(ctx context.Context, in *AddGenderRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminUpdateGender(ctx context.Context, in *GenderResponse, opts ...grpc.CallOption) (*NoArg, error)
+	AdminDeleteGender(ctx context.Context, in *DeleteCatalogRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminMergeGenders(ctx context.Context, in *MergeCatalogRequest, opts ...grpc.CallOption) (*NoArg, error)
+	GetAllGender(ctx context.Context, in *NoArg, opts ...grpc.CallOption) (UserService_GetAllGenderClient, error)
+	AdminUpsertTranslation(ctx context.Context, in *TranslationRequest, opts ...grpc.CallOption) (*NoArg, error)
+	AdminDeleteTranslation(ctx context.Context, in *TranslationKey, opts ...grpc.CallOption) (*NoArg, error)
+	AddGenderUser(ctx context.Context, in *UpdateGenderRequest, opts ...grpc.CallOption) (*NoArg, error)
+	GetAllGenderUser(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (UserService_GetAllGenderUserClient, error)
+	RemoveGenderUser(ctx context.Context, in *UpdateGenderRequest, opts ...grpc.CallOption) (*NoArg, error)
+	UserAddPreference(ctx context.Context, in *PreferenceRequest, opts ...grpc.CallOption) (*NoArg, error)
+	UserEditPreference(ctx context.Context, in *PreferenceResponse, opts ...grpc.CallOption) (*NoArg, error)
+	GetAllPreference(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*PreferenceResponse, error)
+	UserUploadProfileImage(ctx context.Context, in *UserImageRequest, opts ...grpc.CallOption) (*UserImageResponse, error)
+	UploadProfileImageStream(ctx context.Context, opts ...grpc.CallOption) (UserService_UploadProfileImageStreamClient, error)
+	UserGetProfilePic(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserImageResponse, error)
+	HomePage(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*HomeResponse, error)
+	RewindLastSwipe(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*RewindResponse, error)
+	IsUserExist(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*IsUserExistResponse, error)
+	GetUserData(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserDataResponse, error)
+	DecrementLikeCount(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*NoArg, error)
+	RecordLike(ctx context.Context, in *UserLikeRequest, opts ...grpc.CallOption) (*NoArg, error)
+	RemoveLike(ctx context.Context, in *UserLikeRequest, opts ...grpc.CallOption) (*NoArg, error)
+	ImportLikes(ctx context.Context, opts ...grpc.CallOption) (UserService_ImportLikesClient, error)
+	UpdateSubscription(ctx context.Context, in *UpdateSubscriptionRequest, opts ...grpc.CallOption) (*NoArg, error)
+	ApplySubscriptionEvent(ctx context.Context, in *SubscriptionEventRequest, opts ...grpc.CallOption) (*SubscriptionEventResponse, error)
+	GetAllPlans(ctx context.Context, in *NoArg, opts ...grpc.CallOption) (UserService_GetAllPlansClient, error)
+	GetEntitlements(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*EntitlementsResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) UserSignup(ctx context.Context, in *UserSignupRequest, opts ...grpc.CallOption) (*UserSignupResponse, error) {
+	out := new(UserSignupResponse)
+	err := c.cc.Invoke(ctx, UserService_UserSignup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserLogin(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*UserSignupResponse, error) {
+	out := new(UserSignupResponse)
+	err := c.cc.Invoke(ctx, UserService_UserLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminLogin(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*UserSignupResponse, error) {
+	out := new(UserSignupResponse)
+	err := c.cc.Invoke(ctx, UserService_AdminLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateProfile(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_CreateProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error) {
+	out := new(ProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetProfile(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*ProfileResponse, error) {
+	out := new(ProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_GetProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ConfirmAccountChange(ctx context.Context, in *ConfirmAccountChangeRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, UserService_ConfirmAccountChange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequestAccountDeletion(ctx context.Context, in *AccountDeletionRequest, opts ...grpc.CallOption) (*AccountDeletionResponse, error) {
+	out := new(AccountDeletionResponse)
+	err := c.cc.Invoke(ctx, UserService_RequestAccountDeletion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportMyData(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (UserService_ExportMyDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_ExportMyData_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceExportMyDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_ExportMyDataClient interface {
+	Recv() (*DataExportChunk, error)
+	grpc.ClientStream
+}
+
+type userServiceExportMyDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceExportMyDataClient) Recv() (*DataExportChunk, error) {
+	m := new(DataExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) SetDiscoveryMode(ctx context.Context, in *DiscoveryModeRequest, opts ...grpc.CallOption) (*DiscoveryModeResponse, error) {
+	out := new(DiscoveryModeResponse)
+	err := c.cc.Invoke(ctx, UserService_SetDiscoveryMode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetDiscoveryMode(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*DiscoveryModeResponse, error) {
+	out := new(DiscoveryModeResponse)
+	err := c.cc.Invoke(ctx, UserService_GetDiscoveryMode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserSignupResponse, error) {
+	out := new(UserSignupResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminAddInterest(ctx context.Context, in *AddInterestRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminAddInterest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminDeleteInterest(ctx context.Context, in *DeleteCatalogRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminDeleteInterest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminMergeInterests(ctx context.Context, in *MergeCatalogRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminMergeInterests_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminUpdateInterest(ctx context.Context, in *InterestResponse, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminUpdateInterest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAllInterest(ctx context.Context, in *InterestFilter, opts ...grpc.CallOption) (UserService_GetAllInterestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[1], UserService_GetAllInterest_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceGetAllInterestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_GetAllInterestClient interface {
+	Recv() (*InterestResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceGetAllInterestClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceGetAllInterestClient) Recv() (*InterestResponse, error) {
+	m := new(InterestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) AdminAddInterestCategory(ctx context.Context, in *InterestCategoryRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminAddInterestCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminUpdateInterestCategory(ctx context.Context, in *InterestCategoryResponse, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminUpdateInterestCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminDeleteInterestCategory(ctx context.Context, in *DeleteCatalogRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminDeleteInterestCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAllInterestCategories(ctx context.Context, in *NoArg, opts ...grpc.CallOption) (UserService_GetAllInterestCategoriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[2], UserService_GetAllInterestCategories_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceGetAllInterestCategoriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_GetAllInterestCategoriesClient interface {
+	Recv() (*InterestCategoryResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceGetAllInterestCategoriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceGetAllInterestCategoriesClient) Recv() (*InterestCategoryResponse, error) {
+	m := new(InterestCategoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) AddInterestUser(ctx context.Context, in *DeleteInterestRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AddInterestUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteInterestUser(ctx context.Context, in *DeleteInterestRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_DeleteInterestUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetUserInterests(ctx context.Context, in *SetUserInterestsRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_SetUserInterests_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetInterestById(ctx context.Context, in *GetInterestByIdRequest, opts ...grpc.CallOption) (*InterestResponse, error) {
+	out := new(InterestResponse)
+	err := c.cc.Invoke(ctx, UserService_GetInterestById_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAllInterestsUser(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (UserService_GetAllInterestsUserClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[3], UserService_GetAllInterestsUser_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceGetAllInterestsUserClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_GetAllInterestsUserClient interface {
+	Recv() (*InterestResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceGetAllInterestsUserClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceGetAllInterestsUserClient) Recv() (*InterestResponse, error) {
+	m := new(InterestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) UserAddAge(ctx context.Context, in *UserAgeRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_UserAddAge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserGetAge(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserAgeResponse, error) {
+	out := new(UserAgeResponse)
+	err := c.cc.Invoke(ctx, UserService_UserGetAge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserAddAddress(ctx context.Context, in *AddAddressRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_UserAddAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserEditAddress(ctx context.Context, in *AddressResponse, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_UserEditAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserGetAddress(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*AddressResponse, error) {
+	out := new(AddressResponse)
+	err := c.cc.Invoke(ctx, UserService_UserGetAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_UpdateLocation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminAddGender(ctx context.Context, in *AddGenderRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminAddGender_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminUpdateGender(ctx context.Context, in *GenderResponse, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminUpdateGender_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminDeleteGender(ctx context.Context, in *DeleteCatalogRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminDeleteGender_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminMergeGenders(ctx context.Context, in *MergeCatalogRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminMergeGenders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAllGender(ctx context.Context, in *NoArg, opts ...grpc.CallOption) (UserService_GetAllGenderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[4], UserService_GetAllGender_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceGetAllGenderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_GetAllGenderClient interface {
+	Recv() (*GenderResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceGetAllGenderClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceGetAllGenderClient) Recv() (*GenderResponse, error) {
+	m := new(GenderResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) AdminUpsertTranslation(ctx context.Context, in *TranslationRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminUpsertTranslation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AdminDeleteTranslation(ctx context.Context, in *TranslationKey, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AdminDeleteTranslation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AddGenderUser(ctx context.Context, in *UpdateGenderRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_AddGenderUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAllGenderUser(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (UserService_GetAllGenderUserClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[5], UserService_GetAllGenderUser_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceGetAllGenderUserClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_GetAllGenderUserClient interface {
+	Recv() (*GenderResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceGetAllGenderUserClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceGetAllGenderUserClient) Recv() (*GenderResponse, error) {
+	m := new(GenderResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) RemoveGenderUser(ctx context.Context, in *UpdateGenderRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_RemoveGenderUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserAddPreference(ctx context.Context, in *PreferenceRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_UserAddPreference_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserEditPreference(ctx context.Context, in *PreferenceResponse, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_UserEditPreference_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAllPreference(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*PreferenceResponse, error) {
+	out := new(PreferenceResponse)
+	err := c.cc.Invoke(ctx, UserService_GetAllPreference_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UserUploadProfileImage(ctx context.Context, in *UserImageRequest, opts ...grpc.CallOption) (*UserImageResponse, error) {
+	out := new(UserImageResponse)
+	err := c.cc.Invoke(ctx, UserService_UserUploadProfileImage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UploadProfileImageStream(ctx context.Context, opts ...grpc.CallOption) (UserService_UploadProfileImageStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[6], UserService_UploadProfileImageStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceUploadProfileImageStreamClient{stream}
+	return x, nil
+}
+
+type UserService_UploadProfileImageStreamClient interface {
+	Send(*UploadImageChunk) error
+	CloseAndRecv() (*UserImageResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceUploadProfileImageStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceUploadProfileImageStreamClient) Send(m *UploadImageChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *userServiceUploadProfileImageStreamClient) CloseAndRecv() (*UserImageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UserImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) UserGetProfilePic(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserImageResponse, error) {
+	out := new(UserImageResponse)
+	err := c.cc.Invoke(ctx, UserService_UserGetProfilePic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) HomePage(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*HomeResponse, error) {
+	out := new(HomeResponse)
+	err := c.cc.Invoke(ctx, UserService_HomePage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RewindLastSwipe(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*RewindResponse, error) {
+	out := new(RewindResponse)
+	err := c.cc.Invoke(ctx, UserService_RewindLastSwipe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) IsUserExist(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*IsUserExistResponse, error) {
+	out := new(IsUserExistResponse)
+	err := c.cc.Invoke(ctx, UserService_IsUserExist_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserData(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*UserDataResponse, error) {
+	out := new(UserDataResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserData_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DecrementLikeCount(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_DecrementLikeCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RecordLike(ctx context.Context, in *UserLikeRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_RecordLike_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemoveLike(ctx context.Context, in *UserLikeRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_RemoveLike_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ImportLikes(ctx context.Context, opts ...grpc.CallOption) (UserService_ImportLikesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[7], UserService_ImportLikes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceImportLikesClient{stream}
+	return x, nil
+}
+
+type UserService_ImportLikesClient interface {
+	Send(*UserLikeRequest) error
+	CloseAndRecv() (*ImportLikesResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceImportLikesClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceImportLikesClient) Send(m *UserLikeRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *userServiceImportLikesClient) CloseAndRecv() (*ImportLikesResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportLikesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) UpdateSubscription(ctx context.Context, in *UpdateSubscriptionRequest, opts ...grpc.CallOption) (*NoArg, error) {
+	out := new(NoArg)
+	err := c.cc.Invoke(ctx, UserService_UpdateSubscription_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ApplySubscriptionEvent(ctx context.Context, in *SubscriptionEventRequest, opts ...grpc.CallOption) (*SubscriptionEventResponse, error) {
+	out := new(SubscriptionEventResponse)
+	err := c.cc.Invoke(ctx, UserService_ApplySubscriptionEvent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAllPlans(ctx context.Context, in *NoArg, opts ...grpc.CallOption) (UserService_GetAllPlansClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[8], UserService_GetAllPlans_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceGetAllPlansClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_GetAllPlansClient interface {
+	Recv() (*PlanResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceGetAllPlansClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceGetAllPlansClient) Recv() (*PlanResponse, error) {
+	m := new(PlanResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) GetEntitlements(ctx context.Context, in *GetUserById, opts ...grpc.CallOption) (*EntitlementsResponse, error) {
+	out := new(EntitlementsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetEntitlements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility
+type UserServiceServer interface {
+	UserSignup(context.Context, *UserSignupRequest) (*UserSignupResponse, error)
+	UserLogin(context.Context, *LoginRequest) (*UserSignupResponse, error)
+	AdminLogin(context.Context, *LoginRequest) (*UserSignupResponse, error)
+	CreateProfile(context.Context, *GetUserById) (*NoArg, error)
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*ProfileResponse, error)
+	GetProfile(context.Context, *GetUserById) (*ProfileResponse, error)
+	UpdateAccount(context.Context, *UpdateAccountRequest) (*AccountResponse, error)
+	ConfirmAccountChange(context.Context, *ConfirmAccountChangeRequest) (*AccountResponse, error)
+	RequestAccountDeletion(context.Context, *AccountDeletionRequest) (*AccountDeletionResponse, error)
+	ExportMyData(*GetUserById, UserService_ExportMyDataServer) error
+	SetDiscoveryMode(context.Context, *DiscoveryModeRequest) (*DiscoveryModeResponse, error)
+	GetDiscoveryMode(context.Context, *GetUserById) (*DiscoveryModeResponse, error)
+	GetUser(context.Context, *GetUserById) (*UserSignupResponse, error)
+	AdminAddInterest(context.Context, *AddInterestRequest) (*NoArg, error)
+	AdminDeleteInterest(context.Context, *DeleteCatalogRequest) (*NoArg, error)
+	AdminMergeInterests(context.Context, *MergeCatalogRequest) (*NoArg, error)
+	AdminUpdateInterest(context.Context, *InterestResponse) (*NoArg, error)
+	GetAllInterest(*InterestFilter, UserService_GetAllInterestServer) error
+	AdminAddInterestCategory(context.Context, *InterestCategoryRequest) (*NoArg, error)
+	AdminUpdateInterestCategory(context.Context, *InterestCategoryResponse) (*NoArg, error)
+	AdminDeleteInterestCategory(context.Context, *DeleteCatalogRequest) (*NoArg, error)
+	GetAllInterestCategories(*NoArg, UserService_GetAllInterestCategoriesServer) error
+	AddInterestUser(context.Context, *DeleteInterestRequest) (*NoArg, error)
+	DeleteInterestUser(context.Context, *DeleteInterestRequest) (*NoArg, error)
+	SetUserInterests(context.Context, *SetUserInterestsRequest) (*NoArg, error)
+	GetInterestById(context.Context, *GetInterestByIdRequest) (*InterestResponse, error)
+	GetAllInterestsUser(*GetUserById, UserService_GetAllInterestsUserServer) error
+	UserAddAge(context.Context, *UserAgeRequest) (*NoArg, error)
+	UserGetAge(context.Context, *GetUserById) (*UserAgeResponse, error)
+	UserAddAddress(context.Context, *AddAddressRequest) (*NoArg, error)
+	UserEditAddress(context.Context, *AddressResponse) (*NoArg, error)
+	UserGetAddress(context.Context, *GetUserById) (*AddressResponse, error)
+	UpdateLocation(context.Context, *UpdateLocationRequest) (*NoArg, error)
+	AdminAddGender(context.Context, *AddGenderRequest) (*NoArg, error)
+	AdminUpdateGender(context.Context, *GenderResponse) (*NoArg, error)
+	AdminDeleteGender(context.Context, *DeleteCatalogRequest) (*NoArg, error)
+	AdminMergeGenders(context.Context, *MergeCatalogRequest) (*NoArg, error)
+	GetAllGender(*NoArg, UserService_GetAllGenderServer) error
+	AdminUpsertTranslation(context.Context, *TranslationRequest) (*NoArg, error)
+	AdminDeleteTranslation(context.Context, *TranslationKey) (*NoArg, error)
+	AddGenderUser(context.Context, *UpdateGenderRequest) (*NoArg, error)
+	GetAllGenderUser(*GetUserById, UserService_GetAllGenderUserServer) error
+	RemoveGenderUser(context.Context, *UpdateGenderRequest) (*NoArg, error)
+	UserAddPreference(context.Context, *PreferenceRequest) (*NoArg, error)
+	UserEditPreference(context.Context, *PreferenceResponse) (*NoArg, error)
+	GetAllPreference(context.Context, *GetUserById) (*PreferenceResponse, error)
+	UserUploadProfileImage(context.Context, *UserImageRequest) (*UserImageResponse, error)
+	UploadProfileImageStream(UserService_UploadProfileImageStreamServer) error
+	UserGetProfilePic(context.Context, *GetUserById) (*UserImageResponse, error)
+	HomePage(context.Context, *GetUserById) (*HomeResponse, error)
+	RewindLastSwipe(context.Context, *GetUserById) (*RewindResponse, error)
+	IsUserExist(context.Context, *GetUserById) (*IsUserExistResponse, error)
+	GetUserData(context.Context, *GetUserById) (*UserDataResponse, error)
+	DecrementLikeCount(context.Context, *GetUserById) (*NoArg, error)
+	RecordLike(context.Context, *UserLikeRequest) (*NoArg, error)
+	RemoveLike(context.Context, *UserLikeRequest) (*NoArg, error)
+	ImportLikes(UserService_ImportLikesServer) error
+	UpdateSubscription(context.Context, *UpdateSubscriptionRequest) (*NoArg, error)
+	ApplySubscriptionEvent(context.Context, *SubscriptionEventRequest) (*SubscriptionEventResponse, error)
+	GetAllPlans(*NoArg, UserService_GetAllPlansServer) error
+	GetEntitlements(context.Context, *GetUserById) (*EntitlementsResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (UnimplementedUserServiceServer) UserSignup(context.Context, *UserSignupRequest) (*UserSignupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSignup not implemented")
+}
+func (UnimplementedUserServiceServer) UserLogin(context.Context, *LoginRequest) (*UserSignupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserLogin not implemented")
+}
+func (UnimplementedUserServiceServer) AdminLogin(context.Context, *LoginRequest) (*UserSignupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminLogin not implemented")
+}
+func (UnimplementedUserServiceServer) CreateProfile(context.Context, *GetUserById) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProfile not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*ProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (UnimplementedUserServiceServer) GetProfile(context.Context, *GetUserById) (*ProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateAccount(context.Context, *UpdateAccountRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccount not implemented")
+}
+func (UnimplementedUserServiceServer) ConfirmAccountChange(context.Context, *ConfirmAccountChangeRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmAccountChange not implemented")
+}
+func (UnimplementedUserServiceServer) RequestAccountDeletion(context.Context, *AccountDeletionRequest) (*AccountDeletionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAccountDeletion not implemented")
+}
+func (UnimplementedUserServiceServer) ExportMyData(*GetUserById, UserService_ExportMyDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedUserServiceServer) SetDiscoveryMode(context.Context, *DiscoveryModeRequest) (*DiscoveryModeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDiscoveryMode not implemented")
+}
+func (UnimplementedUserServiceServer) GetDiscoveryMode(context.Context, *GetUserById) (*DiscoveryModeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiscoveryMode not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserById) (*UserSignupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) AdminAddInterest(context.Context, *AddInterestRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminAddInterest not implemented")
+}
+func (UnimplementedUserServiceServer) AdminDeleteInterest(context.Context, *DeleteCatalogRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminDeleteInterest not implemented")
+}
+func (UnimplementedUserServiceServer) AdminMergeInterests(context.Context, *MergeCatalogRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminMergeInterests not implemented")
+}
+func (UnimplementedUserServiceServer) AdminUpdateInterest(context.Context, *InterestResponse) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminUpdateInterest not implemented")
+}
+func (UnimplementedUserServiceServer) GetAllInterest(*InterestFilter, UserService_GetAllInterestServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllInterest not implemented")
+}
+func (UnimplementedUserServiceServer) AdminAddInterestCategory(context.Context, *InterestCategoryRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminAddInterestCategory not implemented")
+}
+func (UnimplementedUserServiceServer) AdminUpdateInterestCategory(context.Context, *InterestCategoryResponse) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminUpdateInterestCategory not implemented")
+}
+func (UnimplementedUserServiceServer) AdminDeleteInterestCategory(context.Context, *DeleteCatalogRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminDeleteInterestCategory not implemented")
+}
+func (UnimplementedUserServiceServer) GetAllInterestCategories(*NoArg, UserService_GetAllInterestCategoriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllInterestCategories not implemented")
+}
+func (UnimplementedUserServiceServer) AddInterestUser(context.Context, *DeleteInterestRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddInterestUser not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteInterestUser(context.Context, *DeleteInterestRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInterestUser not implemented")
+}
+func (UnimplementedUserServiceServer) SetUserInterests(context.Context, *SetUserInterestsRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserInterests not implemented")
+}
+func (UnimplementedUserServiceServer) GetInterestById(context.Context, *GetInterestByIdRequest) (*InterestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInterestById not implemented")
+}
+func (UnimplementedUserServiceServer) GetAllInterestsUser(*GetUserById, UserService_GetAllInterestsUserServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllInterestsUser not implemented")
+}
+func (UnimplementedUserServiceServer) UserAddAge(context.Context, *UserAgeRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAddAge not implemented")
+}
+func (UnimplementedUserServiceServer) UserGetAge(context.Context, *GetUserById) (*UserAgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetAge not implemented")
+}
+func (UnimplementedUserServiceServer) UserAddAddress(context.Context, *AddAddressRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAddAddress not implemented")
+}
+func (UnimplementedUserServiceServer) UserEditAddress(context.Context, *AddressResponse) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserEditAddress not implemented")
+}
+func (UnimplementedUserServiceServer) UserGetAddress(context.Context, *GetUserById) (*AddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetAddress not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateLocation(context.Context, *UpdateLocationRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLocation not implemented")
+}
+func (UnimplementedUserServiceServer) AdminAddGender(context.Context, *AddGenderRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminAddGender not implemented")
+}
+func (UnimplementedUserServiceServer) AdminUpdateGender(context.Context, *GenderResponse) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminUpdateGender not implemented")
+}
+func (UnimplementedUserServiceServer) AdminDeleteGender(context.Context, *DeleteCatalogRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminDeleteGender not implemented")
+}
+func (UnimplementedUserServiceServer) AdminMergeGenders(context.Context, *MergeCatalogRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminMergeGenders not implemented")
+}
+func (UnimplementedUserServiceServer) GetAllGender(*NoArg, UserService_GetAllGenderServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllGender not implemented")
+}
+func (UnimplementedUserServiceServer) AdminUpsertTranslation(context.Context, *TranslationRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminUpsertTranslation not implemented")
+}
+func (UnimplementedUserServiceServer) AdminDeleteTranslation(context.Context, *TranslationKey) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminDeleteTranslation not implemented")
+}
+func (UnimplementedUserServiceServer) AddGenderUser(context.Context, *UpdateGenderRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGenderUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetAllGenderUser(*GetUserById, UserService_GetAllGenderUserServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllGenderUser not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveGenderUser(context.Context, *UpdateGenderRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGenderUser not implemented")
+}
+func (UnimplementedUserServiceServer) UserAddPreference(context.Context, *PreferenceRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAddPreference not implemented")
+}
+func (UnimplementedUserServiceServer) UserEditPreference(context.Context, *PreferenceResponse) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserEditPreference not implemented")
+}
+func (UnimplementedUserServiceServer) GetAllPreference(context.Context, *GetUserById) (*PreferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllPreference not implemented")
+}
+func (UnimplementedUserServiceServer) UserUploadProfileImage(context.Context, *UserImageRequest) (*UserImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserUploadProfileImage not implemented")
+}
+func (UnimplementedUserServiceServer) UploadProfileImageStream(UserService_UploadProfileImageStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadProfileImageStream not implemented")
+}
+func (UnimplementedUserServiceServer) UserGetProfilePic(context.Context, *GetUserById) (*UserImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetProfilePic not implemented")
+}
+func (UnimplementedUserServiceServer) HomePage(context.Context, *GetUserById) (*HomeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HomePage not implemented")
+}
+func (UnimplementedUserServiceServer) RewindLastSwipe(context.Context, *GetUserById) (*RewindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RewindLastSwipe not implemented")
+}
+func (UnimplementedUserServiceServer) IsUserExist(context.Context, *GetUserById) (*IsUserExistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsUserExist not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserData(context.Context, *GetUserById) (*UserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserData not implemented")
+}
+func (UnimplementedUserServiceServer) DecrementLikeCount(context.Context, *GetUserById) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecrementLikeCount not implemented")
+}
+func (UnimplementedUserServiceServer) RecordLike(context.Context, *UserLikeRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordLike not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveLike(context.Context, *UserLikeRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLike not implemented")
+}
+func (UnimplementedUserServiceServer) ImportLikes(UserService_ImportLikesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportLikes not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateSubscription(context.Context, *UpdateSubscriptionRequest) (*NoArg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSubscription not implemented")
+}
+func (UnimplementedUserServiceServer) ApplySubscriptionEvent(context.Context, *SubscriptionEventRequest) (*SubscriptionEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplySubscriptionEvent not implemented")
+}
+func (UnimplementedUserServiceServer) GetAllPlans(*NoArg, UserService_GetAllPlansServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllPlans not implemented")
+}
+func (UnimplementedUserServiceServer) GetEntitlements(context.Context, *GetUserById) (*EntitlementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntitlements not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_UserSignup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSignupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserSignup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserSignup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserSignup(ctx, req.(*UserSignupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserLogin(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminLogin(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateProfile(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetProfile(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ConfirmAccountChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmAccountChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConfirmAccountChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ConfirmAccountChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConfirmAccountChange(ctx, req.(*ConfirmAccountChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequestAccountDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountDeletionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestAccountDeletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestAccountDeletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestAccountDeletion(ctx, req.(*AccountDeletionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportMyData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetUserById)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportMyData(m, &userServiceExportMyDataServer{stream})
+}
+
+type UserService_ExportMyDataServer interface {
+	Send(*DataExportChunk) error
+	grpc.ServerStream
+}
+
+type userServiceExportMyDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceExportMyDataServer) Send(m *DataExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_SetDiscoveryMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoveryModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetDiscoveryMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetDiscoveryMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetDiscoveryMode(ctx, req.(*DiscoveryModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetDiscoveryMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetDiscoveryMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetDiscoveryMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetDiscoveryMode(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminAddInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddInterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminAddInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminAddInterest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminAddInterest(ctx, req.(*AddInterestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminDeleteInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminDeleteInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminDeleteInterest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminDeleteInterest(ctx, req.(*DeleteCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminMergeInterests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminMergeInterests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminMergeInterests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminMergeInterests(ctx, req.(*MergeCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminUpdateInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterestResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminUpdateInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminUpdateInterest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminUpdateInterest(ctx, req.(*InterestResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAllInterest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InterestFilter)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).GetAllInterest(m, &userServiceGetAllInterestServer{stream})
+}
+
+type UserService_GetAllInterestServer interface {
+	Send(*InterestResponse) error
+	grpc.ServerStream
+}
+
+type userServiceGetAllInterestServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceGetAllInterestServer) Send(m *InterestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_AdminAddInterestCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterestCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminAddInterestCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminAddInterestCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminAddInterestCategory(ctx, req.(*InterestCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminUpdateInterestCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterestCategoryResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminUpdateInterestCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminUpdateInterestCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminUpdateInterestCategory(ctx, req.(*InterestCategoryResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminDeleteInterestCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminDeleteInterestCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminDeleteInterestCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminDeleteInterestCategory(ctx, req.(*DeleteCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAllInterestCategories_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NoArg)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).GetAllInterestCategories(m, &userServiceGetAllInterestCategoriesServer{stream})
+}
+
+type UserService_GetAllInterestCategoriesServer interface {
+	Send(*InterestCategoryResponse) error
+	grpc.ServerStream
+}
+
+type userServiceGetAllInterestCategoriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceGetAllInterestCategoriesServer) Send(m *InterestCategoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_AddInterestUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddInterestUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddInterestUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddInterestUser(ctx, req.(*DeleteInterestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteInterestUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteInterestUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteInterestUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteInterestUser(ctx, req.(*DeleteInterestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetUserInterests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserInterestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetUserInterests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetUserInterests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetUserInterests(ctx, req.(*SetUserInterestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetInterestById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInterestByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetInterestById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetInterestById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetInterestById(ctx, req.(*GetInterestByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAllInterestsUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetUserById)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).GetAllInterestsUser(m, &userServiceGetAllInterestsUserServer{stream})
+}
+
+type UserService_GetAllInterestsUserServer interface {
+	Send(*InterestResponse) error
+	grpc.ServerStream
+}
+
+type userServiceGetAllInterestsUserServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceGetAllInterestsUserServer) Send(m *InterestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_UserAddAge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserAddAge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserAddAge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserAddAge(ctx, req.(*UserAgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserGetAge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserGetAge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserGetAge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserGetAge(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserAddAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserAddAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserAddAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserAddAddress(ctx, req.(*AddAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserEditAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserEditAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserEditAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserEditAddress(ctx, req.(*AddressResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserGetAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserGetAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserGetAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserGetAddress(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateLocation(ctx, req.(*UpdateLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminAddGender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddGenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminAddGender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminAddGender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminAddGender(ctx, req.(*AddGenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminUpdateGender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenderResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminUpdateGender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminUpdateGender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminUpdateGender(ctx, req.(*GenderResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminDeleteGender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminDeleteGender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminDeleteGender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminDeleteGender(ctx, req.(*DeleteCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminMergeGenders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminMergeGenders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminMergeGenders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminMergeGenders(ctx, req.(*MergeCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAllGender_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NoArg)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).GetAllGender(m, &userServiceGetAllGenderServer{stream})
+}
+
+type UserService_GetAllGenderServer interface {
+	Send(*GenderResponse) error
+	grpc.ServerStream
+}
+
+type userServiceGetAllGenderServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceGetAllGenderServer) Send(m *GenderResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_AdminUpsertTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminUpsertTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminUpsertTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminUpsertTranslation(ctx, req.(*TranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AdminDeleteTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslationKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AdminDeleteTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AdminDeleteTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AdminDeleteTranslation(ctx, req.(*TranslationKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AddGenderUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddGenderUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddGenderUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddGenderUser(ctx, req.(*UpdateGenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAllGenderUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetUserById)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).GetAllGenderUser(m, &userServiceGetAllGenderUserServer{stream})
+}
+
+type UserService_GetAllGenderUserServer interface {
+	Send(*GenderResponse) error
+	grpc.ServerStream
+}
+
+type userServiceGetAllGenderUserServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceGetAllGenderUserServer) Send(m *GenderResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_RemoveGenderUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveGenderUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveGenderUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveGenderUser(ctx, req.(*UpdateGenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserAddPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserAddPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserAddPreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserAddPreference(ctx, req.(*PreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserEditPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreferenceResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserEditPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserEditPreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserEditPreference(ctx, req.(*PreferenceResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAllPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetAllPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetAllPreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetAllPreference(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserUploadProfileImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserUploadProfileImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserUploadProfileImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserUploadProfileImage(ctx, req.(*UserImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UploadProfileImageStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).UploadProfileImageStream(&userServiceUploadProfileImageStreamServer{stream})
+}
+
+type UserService_UploadProfileImageStreamServer interface {
+	SendAndClose(*UserImageResponse) error
+	Recv() (*UploadImageChunk, error)
+	grpc.ServerStream
+}
+
+type userServiceUploadProfileImageStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceUploadProfileImageStreamServer) SendAndClose(m *UserImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *userServiceUploadProfileImageStreamServer) Recv() (*UploadImageChunk, error) {
+	m := new(UploadImageChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _UserService_UserGetProfilePic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserGetProfilePic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserGetProfilePic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserGetProfilePic(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_HomePage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).HomePage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_HomePage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).HomePage(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RewindLastSwipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RewindLastSwipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RewindLastSwipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RewindLastSwipe(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_IsUserExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).IsUserExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_IsUserExist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).IsUserExist(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserData(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DecrementLikeCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DecrementLikeCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DecrementLikeCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DecrementLikeCount(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RecordLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RecordLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RecordLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RecordLike(ctx, req.(*UserLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveLike(ctx, req.(*UserLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ImportLikes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).ImportLikes(&userServiceImportLikesServer{stream})
+}
+
+type UserService_ImportLikesServer interface {
+	SendAndClose(*ImportLikesResponse) error
+	Recv() (*UserLikeRequest, error)
+	grpc.ServerStream
+}
+
+type userServiceImportLikesServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceImportLikesServer) SendAndClose(m *ImportLikesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *userServiceImportLikesServer) Recv() (*UserLikeRequest, error) {
+	m := new(UserLikeRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _UserService_UpdateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateSubscription(ctx, req.(*UpdateSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ApplySubscriptionEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscriptionEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ApplySubscriptionEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ApplySubscriptionEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ApplySubscriptionEvent(ctx, req.(*SubscriptionEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAllPlans_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NoArg)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).GetAllPlans(m, &userServiceGetAllPlansServer{stream})
+}
+
+type UserService_GetAllPlansServer interface {
+	Send(*PlanResponse) error
+	grpc.ServerStream
+}
+
+type userServiceGetAllPlansServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceGetAllPlansServer) Send(m *PlanResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_GetEntitlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetEntitlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetEntitlements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetEntitlements(ctx, req.(*GetUserById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "user.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UserSignup",
+			Handler:    _UserService_UserSignup_Handler,
+		},
+		{
+			MethodName: "UserLogin",
+			Handler:    _UserService_UserLogin_Handler,
+		},
+		{
+			MethodName: "AdminLogin",
+			Handler:    _UserService_AdminLogin_Handler,
+		},
+		{
+			MethodName: "CreateProfile",
+			Handler:    _UserService_CreateProfile_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _UserService_UpdateProfile_Handler,
+		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _UserService_GetProfile_Handler,
+		},
+		{
+			MethodName: "UpdateAccount",
+			Handler:    _UserService_UpdateAccount_Handler,
+		},
+		{
+			MethodName: "ConfirmAccountChange",
+			Handler:    _UserService_ConfirmAccountChange_Handler,
+		},
+		{
+			MethodName: "RequestAccountDeletion",
+			Handler:    _UserService_RequestAccountDeletion_Handler,
+		},
+		{
+			MethodName: "SetDiscoveryMode",
+			Handler:    _UserService_SetDiscoveryMode_Handler,
+		},
+		{
+			MethodName: "GetDiscoveryMode",
+			Handler:    _UserService_GetDiscoveryMode_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "AdminAddInterest",
+			Handler:    _UserService_AdminAddInterest_Handler,
+		},
+		{
+			MethodName: "AdminDeleteInterest",
+			Handler:    _UserService_AdminDeleteInterest_Handler,
+		},
+		{
+			MethodName: "AdminMergeInterests",
+			Handler:    _UserService_AdminMergeInterests_Handler,
+		},
+		{
+			MethodName: "AdminUpdateInterest",
+			Handler:    _UserService_AdminUpdateInterest_Handler,
+		},
+		{
+			MethodName: "AdminAddInterestCategory",
+			Handler:    _UserService_AdminAddInterestCategory_Handler,
+		},
+		{
+			MethodName: "AdminUpdateInterestCategory",
+			Handler:    _UserService_AdminUpdateInterestCategory_Handler,
+		},
+		{
+			MethodName: "AdminDeleteInterestCategory",
+			Handler:    _UserService_AdminDeleteInterestCategory_Handler,
+		},
+		{
+			MethodName: "AddInterestUser",
+			Handler:    _UserService_AddInterestUser_Handler,
+		},
+		{
+			MethodName: "DeleteInterestUser",
+			Handler:    _UserService_DeleteInterestUser_Handler,
+		},
+		{
+			MethodName: "SetUserInterests",
+			Handler:    _UserService_SetUserInterests_Handler,
+		},
+		{
+			MethodName: "GetInterestById",
+			Handler:    _UserService_GetInterestById_Handler,
+		},
+		{
+			MethodName: "UserAddAge",
+			Handler:    _UserService_UserAddAge_Handler,
+		},
+		{
+			MethodName: "UserGetAge",
+			Handler:    _UserService_UserGetAge_Handler,
+		},
+		{
+			MethodName: "UserAddAddress",
+			Handler:    _UserService_UserAddAddress_Handler,
+		},
+		{
+			MethodName: "UserEditAddress",
+			Handler:    _UserService_UserEditAddress_Handler,
+		},
+		{
+			MethodName: "UserGetAddress",
+			Handler:    _UserService_UserGetAddress_Handler,
+		},
+		{
+			MethodName: "UpdateLocation",
+			Handler:    _UserService_UpdateLocation_Handler,
+		},
+		{
+			MethodName: "AdminAddGender",
+			Handler:    _UserService_AdminAddGender_Handler,
+		},
+		{
+			MethodName: "AdminUpdateGender",
+			Handler:    _UserService_AdminUpdateGender_Handler,
+		},
+		{
+			MethodName: "AdminDeleteGender",
+			Handler:    _UserService_AdminDeleteGender_Handler,
+		},
+		{
+			MethodName: "AdminMergeGenders",
+			Handler:    _UserService_AdminMergeGenders_Handler,
+		},
+		{
+			MethodName: "AdminUpsertTranslation",
+			Handler:    _UserService_AdminUpsertTranslation_Handler,
+		},
+		{
+			MethodName: "AdminDeleteTranslation",
+			Handler:    _UserService_AdminDeleteTranslation_Handler,
+		},
+		{
+			MethodName: "AddGenderUser",
+			Handler:    _UserService_AddGenderUser_Handler,
+		},
+		{
+			MethodName: "RemoveGenderUser",
+			Handler:    _UserService_RemoveGenderUser_Handler,
+		},
+		{
+			MethodName: "UserAddPreference",
+			Handler:    _UserService_UserAddPreference_Handler,
+		},
+		{
+			MethodName: "UserEditPreference",
+			Handler:    _UserService_UserEditPreference_Handler,
+		},
+		{
+			MethodName: "GetAllPreference",
+			Handler:    _UserService_GetAllPreference_Handler,
+		},
+		{
+			MethodName: "UserUploadProfileImage",
+			Handler:    _UserService_UserUploadProfileImage_Handler,
+		},
+		{
+			MethodName: "UserGetProfilePic",
+			Handler:    _UserService_UserGetProfilePic_Handler,
+		},
+		{
+			MethodName: "HomePage",
+			Handler:    _UserService_HomePage_Handler,
+		},
+		{
+			MethodName: "RewindLastSwipe",
+			Handler:    _UserService_RewindLastSwipe_Handler,
+		},
+		{
+			MethodName: "IsUserExist",
+			Handler:    _UserService_IsUserExist_Handler,
+		},
+		{
+			MethodName: "GetUserData",
+			Handler:    _UserService_GetUserData_Handler,
+		},
+		{
+			MethodName: "DecrementLikeCount",
+			Handler:    _UserService_DecrementLikeCount_Handler,
+		},
+		{
+			MethodName: "RecordLike",
+			Handler:    _UserService_RecordLike_Handler,
+		},
+		{
+			MethodName: "RemoveLike",
+			Handler:    _UserService_RemoveLike_Handler,
+		},
+		{
+			MethodName: "UpdateSubscription",
+			Handler:    _UserService_UpdateSubscription_Handler,
+		},
+		{
+			MethodName: "ApplySubscriptionEvent",
+			Handler:    _UserService_ApplySubscriptionEvent_Handler,
+		},
+		{
+			MethodName: "GetEntitlements",
+			Handler:    _UserService_GetEntitlements_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportMyData",
+			Handler:       _UserService_ExportMyData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAllInterest",
+			Handler:       _UserService_GetAllInterest_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAllInterestCategories",
+			Handler:       _UserService_GetAllInterestCategories_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAllInterestsUser",
+			Handler:       _UserService_GetAllInterestsUser_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAllGender",
+			Handler:       _UserService_GetAllGender_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAllGenderUser",
+			Handler:       _UserService_GetAllGenderUser_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadProfileImageStream",
+			Handler:       _UserService_UploadProfileImageStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportLikes",
+			Handler:       _UserService_ImportLikes_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetAllPlans",
+			Handler:       _UserService_GetAllPlans_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "user.proto",
+}
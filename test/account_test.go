@@ -0,0 +1,232 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_UserService/logging"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gorm.io/gorm"
+)
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "a***@example.com", helper.Redact("email", "alice@example.com"))
+	assert.Equal(t, "***********10", helper.Redact("phone", "+919876543210"))
+	assert.Equal(t, "Ä***", helper.Redact("name", "Ärne"))
+	assert.Equal(t, "", helper.Redact("email", ""))
+	for _, email := range []string{"élise@example.com", "no-at-sign"} {
+		assert.Equal(t, logging.MaskEmail(email), helper.Redact("email", email), "audits mask emails as the logs do")
+	}
+	assert.Equal(t, logging.MaskPhone("12"), helper.Redact("phone", "12"), "audits mask phones as the logs do")
+}
+
+func TestUpdateAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	hashed, err := helper.HashPassword("secret")
+	assert.NoError(t, err)
+	account := entities.User{ID: testUUID, Name: "valid", Email: "old@example.com", Phone: "+919876543210", Password: hashed}
+
+	tests := []struct {
+		name       string
+		paths      []string
+		password   string
+		emailOwner entities.User
+		wantUpdate bool
+		wantCode   codes.Code
+	}{
+		{
+			name:       "Success - name applies without a password",
+			paths:      []string{"name"},
+			wantUpdate: true,
+			wantCode:   codes.OK,
+		},
+		{
+			name:       "Success - email stays pending",
+			paths:      []string{"email"},
+			password:   "secret",
+			wantUpdate: true,
+			wantCode:   codes.OK,
+		},
+		{
+			name:     "Fail - wrong current password",
+			paths:    []string{"email"},
+			password: "wrong",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:       "Fail - email used by another account",
+			paths:      []string{"email"},
+			password:   "secret",
+			emailOwner: entities.User{ID: uuid.New(), Name: "other"},
+			wantCode:   codes.AlreadyExists,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAdapters.EXPECT().GetUserById(testUUID.String()).Return(account, nil).AnyTimes()
+			if test.paths[0] == "email" && test.password == "secret" {
+				mockAdapters.EXPECT().GetUserByEmail("new@example.com").Return(test.emailOwner, nil)
+			}
+			var codeHash string
+			if test.wantUpdate {
+				mockAdapters.EXPECT().UpdateAccount(testUUID.String(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(userId string, name *string, pending []entities.PendingAccountChange, audits []entities.AccountAudit) error {
+						if assert.Len(t, audits, 1) {
+							assert.NotContains(t, audits[0].NewValue, "new", "audits are redacted")
+						}
+						if test.paths[0] == "name" {
+							assert.Equal(t, "renamed", *name)
+							assert.Empty(t, pending)
+						} else if assert.Len(t, pending, 1) {
+							assert.Nil(t, name)
+							assert.Equal(t, "new@example.com", pending[0].Value)
+							assert.True(t, pending[0].ExpiresAt.After(time.Now()))
+							codeHash = pending[0].CodeHash
+						}
+						return nil
+					})
+				mockAdapters.EXPECT().GetPendingAccountChanges(testUUID.String()).Return(nil, nil)
+			}
+			res, err := userService.UpdateAccount(context.Background(), &pb.UpdateAccountRequest{
+				UserId:          testUUID.String(),
+				Account:         &pb.AccountDetails{Name: "renamed", Email: "new@example.com"},
+				UpdateMask:      &fieldmaskpb.FieldMask{Paths: test.paths},
+				CurrentPassword: test.password,
+			})
+			assert.Equal(t, test.wantCode, status.Code(err))
+			if err == nil && test.paths[0] == "email" {
+				assert.Len(t, res.EmailCode, 6)
+				assert.Equal(t, helper.HashCode(res.EmailCode), codeHash, "only the digest of the code is stored")
+			} else if err == nil {
+				assert.Empty(t, res.EmailCode)
+			}
+		})
+	}
+}
+
+func TestConfirmAccountChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	account := entities.User{ID: testUUID, Name: "valid", Email: "old@example.com", Phone: "+919876543210"}
+	mockAdapters.EXPECT().GetUserById(testUUID.String()).Return(account, nil).AnyTimes()
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "Success", wantCode: codes.OK},
+		{name: "Fail - nothing pending", err: adapters.ErrNoPendingChange, wantCode: codes.FailedPrecondition},
+		{name: "Fail - wrong code", err: adapters.ErrWrongCode, wantCode: codes.PermissionDenied},
+		{name: "Fail - too many attempts", err: adapters.ErrTooManyAttempts, wantCode: codes.ResourceExhausted},
+		{name: "Fail - taken meanwhile", err: gorm.ErrDuplicatedKey, wantCode: codes.AlreadyExists},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAdapters.EXPECT().ConfirmAccountChange(
+				entities.PendingAccountChange{UserId: testUUID, Field: "phone", Value: "+919876543299", CodeHash: helper.HashCode("123456")},
+				gomock.Any(),
+				entities.AccountAudit{Field: "phone", Event: entities.AccountChangeConfirmed, OldValue: "***********10", NewValue: "***********99"},
+			).Return(test.err)
+			if test.err == nil {
+				mockAdapters.EXPECT().GetPendingAccountChanges(testUUID.String()).Return(nil, nil)
+			}
+			_, err := userService.ConfirmAccountChange(context.Background(), &pb.ConfirmAccountChangeRequest{
+				UserId: testUUID.String(),
+				Field:  "phone",
+				Value:  "+919876543299",
+				Code:   "123456",
+			})
+			assert.Equal(t, test.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestAccountChangePostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	userId, _ := seedUser(t, tx, seedProfile{Name: "user", Age: 27, GenderId: seedGender(t, tx)})
+	email := "new-" + uuid.NewString() + "@example.com"
+	pending := []entities.PendingAccountChange{{Field: "email", Value: email, ExpiresAt: time.Now().Add(time.Hour), CodeHash: helper.HashCode("123456")}}
+	audits := []entities.AccountAudit{{Field: "email", Event: entities.AccountChangeRequested, OldValue: "u***@example.com", NewValue: "n***@example.com"}}
+	assert.NoError(t, repo.UpdateAccount(userId, nil, pending, audits))
+
+	changes, err := repo.GetPendingAccountChanges(userId)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, email, changes[0].Value)
+	}
+	account, err := repo.GetUserById(userId)
+	assert.NoError(t, err)
+	assert.NotEqual(t, email, account.Email, "the email stays pending")
+
+	change := entities.PendingAccountChange{UserId: uuid.MustParse(userId), Field: "email", Value: "other@example.com", CodeHash: helper.HashCode("123456")}
+	assert.ErrorIs(t, repo.ConfirmAccountChange(change, 2, entities.AccountAudit{}), adapters.ErrNoPendingChange)
+
+	change.Value = email
+	change.CodeHash = helper.HashCode("654321")
+	assert.ErrorIs(t, repo.ConfirmAccountChange(change, 2, entities.AccountAudit{}), adapters.ErrWrongCode)
+	changes, err = repo.GetPendingAccountChanges(userId)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1, "a wrong code keeps the change pending") {
+		assert.Equal(t, 1, changes[0].Attempts)
+	}
+	account, err = repo.GetUserById(userId)
+	assert.NoError(t, err)
+	assert.NotEqual(t, email, account.Email, "a wrong code does not apply the change")
+
+	change.CodeHash = helper.HashCode("123456")
+	assert.NoError(t, repo.ConfirmAccountChange(change, 2, entities.AccountAudit{Field: "email", Event: entities.AccountChangeConfirmed}))
+	account, err = repo.GetUserById(userId)
+	assert.NoError(t, err)
+	assert.Equal(t, email, account.Email)
+	changes, err = repo.GetPendingAccountChanges(userId)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	var count int
+	if err := tx.Raw(`SELECT COUNT(*) FROM account_audits WHERE user_id=?`, userId).Scan(&count).Error; err != nil {
+		t.Fatalf("counting audits: %v", err)
+	}
+	assert.Equal(t, 2, count)
+
+	phone := entities.PendingAccountChange{UserId: uuid.MustParse(userId), Field: "phone", Value: "+91" + fmt.Sprint(9000000000+time.Now().UnixNano()%1000000000), ExpiresAt: time.Now().Add(time.Hour), CodeHash: helper.HashCode("111111")}
+	assert.NoError(t, repo.UpdateAccount(userId, nil, []entities.PendingAccountChange{phone}, nil))
+	wrong := phone
+	wrong.CodeHash = helper.HashCode("222222")
+	assert.ErrorIs(t, repo.ConfirmAccountChange(wrong, 1, entities.AccountAudit{}), adapters.ErrWrongCode)
+	assert.ErrorIs(t, repo.ConfirmAccountChange(phone, 1, entities.AccountAudit{}), adapters.ErrTooManyAttempts, "the right code is refused once the attempts are used up")
+	assert.NoError(t, repo.UpdateAccount(userId, nil, []entities.PendingAccountChange{phone}, nil))
+	assert.NoError(t, repo.ConfirmAccountChange(phone, 1, entities.AccountAudit{}), "requesting the change again resets the attempts")
+
+	// The unique index rejects a second account with the same email; this
+	// must be the last statement because the failed insert aborts the
+	// transaction.
+	err = tx.Create(&entities.User{ID: uuid.New(), Name: "copy", Email: email, Phone: uuid.NewString()}).Error
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,154 @@
+package userServiceTest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func candidateNames(users []helperstruct.Home) []string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestFetchCandidates(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	woman, man := seedGender(t, tx), seedGender(t, tx)
+	// A city unique to this test keeps rows from other data out of the results.
+	city := "city-" + uuid.NewString()
+	_, viewer := seedUser(t, tx, seedProfile{Name: "viewer", Age: 28, GenderId: man, City: city, Country: "India"})
+	for _, p := range []seedProfile{
+		{Name: "age-24", Age: 24, GenderId: woman, City: city, Country: "India"},
+		{Name: "age-25", Age: 25, GenderId: woman, City: city, Country: "India"},
+		{Name: "age-30", Age: 30, GenderId: woman, City: city, Country: "India"},
+		{Name: "age-31", Age: 31, GenderId: woman, City: city, Country: "India"},
+		{Name: "man", Age: 27, GenderId: man, City: city, Country: "India"},
+		{Name: "blocked", Age: 27, GenderId: woman, City: city, Country: "India", Blocked: true},
+		{Name: "elsewhere", Age: 27, GenderId: woman, City: city, Country: "Nepal"},
+	} {
+		seedUser(t, tx, p)
+	}
+	seen, _ := seedUser(t, tx, seedProfile{Name: "seen", Age: 27, GenderId: woman, City: city, Country: "India"})
+
+	base := helperstruct.CandidateQuery{ProfileId: viewer, MinAge: 25, MaxAge: 30, City: city}
+	tests := []struct {
+		name   string
+		modify func(*helperstruct.CandidateQuery)
+		want   []string
+	}{
+		{
+			name:   "age bounds are inclusive",
+			modify: func(q *helperstruct.CandidateQuery) { q.GenderIds = []int{woman}; q.Country = "India" },
+			want:   []string{"age-25", "age-30", "blocked", "seen"},
+		},
+		{
+			name:   "several genders",
+			modify: func(q *helperstruct.CandidateQuery) { q.GenderIds = []int{woman, man}; q.Country = "india" },
+			want:   []string{"age-25", "age-30", "blocked", "man", "seen"},
+		},
+		{
+			name: "excludes blocked and seen users",
+			modify: func(q *helperstruct.CandidateQuery) {
+				q.GenderIds = []int{woman}
+				q.ExcludeBlocked = true
+				q.ExcludeUserIds = []string{seen}
+			},
+			want: []string{"age-25", "age-30", "elsewhere"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := base
+			test.modify(&q)
+			users, err := repo.FetchCandidates(q)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, candidateNames(users))
+		})
+	}
+}
+
+func TestFetchCandidatesReciprocal(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	woman, man := seedGender(t, tx), seedGender(t, tx)
+	city, other := "city-"+uuid.NewString(), "city-"+uuid.NewString()
+	_, viewer := seedUser(t, tx, seedProfile{Name: "viewer", Age: 28, GenderId: man, City: city})
+	seedPreference(t, tx, viewer, entities.Preference{MinAge: 25, MaxAge: 30, GenderId: woman, DesireCity: city})
+
+	for _, c := range []struct {
+		name string
+		pref *entities.Preference
+	}{
+		{name: "match", pref: &entities.Preference{MinAge: 25, MaxAge: 35, GenderId: man, DesireCity: city}},
+		{name: "viewer-at-bound", pref: &entities.Preference{MinAge: 28, MaxAge: 28, GenderId: man, DesireCity: strings.ToUpper(city)}},
+		{name: "viewer-too-old", pref: &entities.Preference{MinAge: 20, MaxAge: 27, GenderId: man, DesireCity: city}},
+		{name: "viewer-too-young", pref: &entities.Preference{MinAge: 29, MaxAge: 40, GenderId: man, DesireCity: city}},
+		{name: "wants-women", pref: &entities.Preference{MinAge: 25, MaxAge: 35, GenderId: woman, DesireCity: city}},
+		{name: "wants-other-city", pref: &entities.Preference{MinAge: 25, MaxAge: 35, GenderId: man, DesireCity: other}},
+		{name: "no-preference"},
+		{name: "any-gender", pref: &entities.Preference{MinAge: 25, MaxAge: 35, GenderId: woman, DesireCity: city}},
+	} {
+		_, profile := seedUser(t, tx, seedProfile{Name: c.name, Age: 27, GenderId: woman, City: city})
+		if c.pref != nil {
+			seedPreference(t, tx, profile, *c.pref)
+		}
+		if c.name == "any-gender" {
+			if err := tx.Exec(`DELETE FROM preference_genders WHERE preference_id IN (SELECT id FROM preferences WHERE profile_id=?)`, profile).Error; err != nil {
+				t.Fatalf("clearing preferred genders: %v", err)
+			}
+		}
+	}
+
+	q := helperstruct.CandidateQuery{ProfileId: viewer, MinAge: 25, MaxAge: 30, GenderIds: []int{woman}, City: city}
+	users, err := repo.FetchCandidates(q)
+	assert.NoError(t, err)
+	assert.Len(t, users, 8, "without Reciprocal only the viewer's preference applies")
+
+	q.Reciprocal = true
+	users, err = repo.FetchCandidates(q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"any-gender", "match", "no-preference", "viewer-at-bound"}, candidateNames(users),
+		"a missing preference or an empty gender set accepts the viewer")
+}
+
+func TestFetchCandidateCardsPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	woman := seedGender(t, tx)
+	city := "city-" + uuid.NewString()
+	_, pictured := seedUser(t, tx, seedProfile{Name: "pictured", Age: 27, GenderId: woman, City: city})
+	_, bare := seedUser(t, tx, seedProfile{Name: "bare", Age: 27, GenderId: woman, City: city})
+
+	interest := entities.Interests{Interest: "hiking-" + uuid.NewString()}
+	if err := tx.Create(&interest).Error; err != nil {
+		t.Fatalf("seeding interest: %v", err)
+	}
+	assert.NoError(t, repo.UpsertTranslation(entities.Translation{Entity: entities.TranslationInterest, EntityId: interest.Id, Locale: "pt", Name: "caminhada"}))
+	assert.NoError(t, tx.Exec(`INSERT INTO user_interests (profile_id, interest_id) VALUES (?, ?)`, pictured, interest.Id).Error)
+	assert.NoError(t, tx.Exec(`INSERT INTO images (id, profile_id, file_name) VALUES (?, ?, 'a.jpg'), (?, ?, 'b.jpg')`, uuid.New(), pictured, uuid.New(), pictured).Error)
+
+	cards, err := repo.FetchCandidateCards([]string{pictured, bare}, []string{"pt"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a.jpg", "b.jpg"}, cards[pictured].Images)
+	assert.Equal(t, []string{"caminhada"}, cards[pictured].Interests)
+	assert.Empty(t, cards[bare].Images)
+	assert.Empty(t, cards[bare].Interests)
+
+	cards, err = repo.FetchCandidateCards(nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, cards)
+}
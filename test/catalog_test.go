@@ -0,0 +1,206 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestAdminDeleteInterest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(adapters, nil, nil)
+
+	tests := []struct {
+		name                    string
+		mockGetInterestById     func(int) (helperstruct.InterestHelper, error)
+		mockAdminDeleteInterest func(int) error
+		wantCode                codes.Code
+	}{
+		{
+			name: "Success",
+			mockGetInterestById: func(id int) (helperstruct.InterestHelper, error) {
+				return helperstruct.InterestHelper{InterestId: id, InterestName: "music"}, nil
+			},
+			mockAdminDeleteInterest: func(id int) error {
+				return nil
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Fail - interest not found",
+			mockGetInterestById: func(id int) (helperstruct.InterestHelper, error) {
+				return helperstruct.InterestHelper{}, nil
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Fail - AdminDeleteInterest error",
+			mockGetInterestById: func(id int) (helperstruct.InterestHelper, error) {
+				return helperstruct.InterestHelper{InterestId: id, InterestName: "music"}, nil
+			},
+			mockAdminDeleteInterest: func(id int) error {
+				return fmt.Errorf("delete failed")
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapters.EXPECT().GetInterestById(1).DoAndReturn(test.mockGetInterestById).Times(1)
+			if test.mockAdminDeleteInterest != nil {
+				adapters.EXPECT().AdminDeleteInterest(1).DoAndReturn(test.mockAdminDeleteInterest).Times(1)
+			}
+			_, err := userService.AdminDeleteInterest(context.Background(), &pb.DeleteCatalogRequest{Id: 1})
+			assert.Equal(t, test.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestAdminMergeGenders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(adapters, nil, nil)
+
+	found := func(id int) (helperstruct.GenderHelper, error) {
+		return helperstruct.GenderHelper{GenderId: id, GenderName: "gender"}, nil
+	}
+	tests := []struct {
+		name                  string
+		mockGetGenderById     map[int]func(int) (helperstruct.GenderHelper, error)
+		mockAdminMergeGenders func(int, int) error
+		wantCode              codes.Code
+	}{
+		{
+			name:              "Success",
+			mockGetGenderById: map[int]func(int) (helperstruct.GenderHelper, error){1: found, 2: found},
+			mockAdminMergeGenders: func(source, target int) error {
+				return nil
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Fail - target not found",
+			mockGetGenderById: map[int]func(int) (helperstruct.GenderHelper, error){
+				1: found,
+				2: func(id int) (helperstruct.GenderHelper, error) {
+					return helperstruct.GenderHelper{}, nil
+				},
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Fail - GetGenderById error",
+			mockGetGenderById: map[int]func(int) (helperstruct.GenderHelper, error){
+				1: func(id int) (helperstruct.GenderHelper, error) {
+					return helperstruct.GenderHelper{}, fmt.Errorf("database error")
+				},
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for id, mock := range test.mockGetGenderById {
+				adapters.EXPECT().GetGenderById(id).DoAndReturn(mock).Times(1)
+			}
+			if test.mockAdminMergeGenders != nil {
+				adapters.EXPECT().AdminMergeGenders(1, 2).DoAndReturn(test.mockAdminMergeGenders).Times(1)
+			}
+			_, err := userService.AdminMergeGenders(context.Background(), &pb.MergeCatalogRequest{SourceId: 1, TargetId: 2})
+			assert.Equal(t, test.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestAdminMergeInterestsPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	name := "interest-" + uuid.NewString()
+	source, target := entities.Interests{Interest: name + "-old"}, entities.Interests{Interest: name}
+	for _, i := range []*entities.Interests{&source, &target} {
+		if err := tx.Create(i).Error; err != nil {
+			t.Fatalf("seeding interest: %v", err)
+		}
+	}
+	_, both := seedUser(t, tx, seedProfile{Name: "both", Age: 27, GenderId: seedGender(t, tx)})
+	_, onlySource := seedUser(t, tx, seedProfile{Name: "only-source", Age: 27, GenderId: seedGender(t, tx)})
+	for _, link := range []entities.UserInterests{
+		{ProfileId: uuid.MustParse(both), InterestId: source.Id},
+		{ProfileId: uuid.MustParse(both), InterestId: target.Id},
+		{ProfileId: uuid.MustParse(onlySource), InterestId: source.Id},
+	} {
+		assert.NoError(t, repo.UserAddInterest(link))
+	}
+
+	assert.NoError(t, repo.AdminMergeInterests(source.Id, target.Id))
+	for _, profile := range []string{both, onlySource} {
+		interests, err := repo.UserGetAllInterest(profile, nil)
+		assert.NoError(t, err)
+		if assert.Len(t, interests, 1, "links are moved to the target without duplicates") {
+			assert.Equal(t, target.Id, interests[0].Id)
+		}
+	}
+	merged, err := repo.GetInterestById(source.Id)
+	assert.NoError(t, err)
+	assert.Zero(t, merged.InterestId, "the merged interest is hidden from the catalog")
+
+	// The unique index ignores case; this must be the last statement because
+	// the failed insert aborts the transaction.
+	err = tx.Create(&entities.Interests{Interest: strings.ToUpper(name)}).Error
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
+
+func TestCatalogNamesWithoutIndexPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	// A database holding case-variant duplicates starts without the
+	// indexes; the drop is rolled back with the test transaction.
+	for _, index := range []string{"idx_interests_name", "idx_interest_categories_name", "idx_genders_name"} {
+		if err := tx.Exec(`DROP INDEX IF EXISTS ` + index).Error; err != nil {
+			t.Fatalf("dropping %s: %v", index, err)
+		}
+	}
+	name := "catalog-" + uuid.NewString()
+	assert.NoError(t, repo.AdminAddGender(entities.Gender{Name: name}))
+	assert.ErrorIs(t, repo.AdminAddGender(entities.Gender{Name: name}), gorm.ErrDuplicatedKey)
+	assert.ErrorIs(t, repo.AdminAddGender(entities.Gender{Name: strings.ToUpper(name)}), gorm.ErrDuplicatedKey)
+	other := entities.Gender{Name: name + "-other"}
+	if err := tx.Create(&other).Error; err != nil {
+		t.Fatalf("seeding gender: %v", err)
+	}
+	assert.ErrorIs(t, repo.AdminUpdateGender(entities.Gender{Id: other.Id, Name: strings.ToUpper(name)}), gorm.ErrDuplicatedKey)
+
+	assert.NoError(t, repo.AdminAddInterestCategory(entities.InterestCategory{Name: name}))
+	assert.ErrorIs(t, repo.AdminAddInterestCategory(entities.InterestCategory{Name: strings.ToUpper(name)}), gorm.ErrDuplicatedKey)
+
+	assert.NoError(t, repo.AdminAddInterest(entities.Interests{Interest: name}))
+	assert.NoError(t, repo.AdminAddInterest(entities.Interests{Interest: name + "-other"}))
+	var ids []int
+	if err := tx.Raw(`SELECT id FROM interests WHERE interest IN ? ORDER BY interest`, []string{name, name + "-other"}).Scan(&ids).Error; err != nil || len(ids) != 2 {
+		t.Fatalf("reading interests: %v", err)
+	}
+	assert.ErrorIs(t, repo.AdminUpdateInterest(entities.Interests{Id: ids[1], Interest: strings.ToUpper(name)}), gorm.ErrDuplicatedKey)
+	assert.NoError(t, repo.AdminUpdateInterest(entities.Interests{Id: ids[0], Interest: strings.ToUpper(name)}), "an interest keeps its own name in another case")
+
+	assert.NoError(t, repo.AdminDeleteInterest(ids[0]))
+	assert.NoError(t, repo.AdminAddInterest(entities.Interests{Interest: name}), "deleted names can be added again")
+}
@@ -0,0 +1,179 @@
+package userServiceTest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	content := "DB_KEY=file-db\nMINIO_ENDPOINT=minio:9000\nMINIO_ACCESSKEY=access\nMINIO_SECRETKEY=secret\nBUCKET_NAME=images\nREDIS_ADDR=file-redis:6379\n"
+	if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		env       map[string]string
+		wantError bool
+		missing   []string
+		expected  config.Config
+	}{
+		{
+			name: "Success - file with defaults",
+			args: []string{"-config", envFile},
+			expected: config.Config{
+				Port:                "8081",
+				DBKey:               "file-db",
+				ShutdownTimeout:     15 * time.Second,
+				HealthCheckInterval: 10 * time.Second,
+				Logging:             config.Logging{Format: config.LogFormatText},
+				Tracing:             config.Tracing{Exporter: "none", Endpoint: "localhost:4317", Insecure: true},
+				Redis:               config.Redis{Addr: "file-redis:6379"},
+				Minio:               config.Minio{Endpoint: "minio:9000", AccessKey: "access", SecretKey: "secret", Bucket: "images"},
+				MaxInterestsPerUser: 10,
+				AccountPurgeGrace:   720 * time.Hour,
+				SeenRecycleAfter:    720 * time.Hour,
+			},
+		},
+		{
+			name: "Success - env overrides file and flags override env",
+			args: []string{"-config", envFile, "-port", "9090"},
+			env:  map[string]string{"GRPC_PORT": "7070", "REDIS_ADDR": "env-redis:6379"},
+			expected: config.Config{
+				Port:                "9090",
+				DBKey:               "file-db",
+				ShutdownTimeout:     15 * time.Second,
+				HealthCheckInterval: 10 * time.Second,
+				Logging:             config.Logging{Format: config.LogFormatText},
+				Tracing:             config.Tracing{Exporter: "none", Endpoint: "localhost:4317", Insecure: true},
+				Redis:               config.Redis{Addr: "env-redis:6379"},
+				Minio:               config.Minio{Endpoint: "minio:9000", AccessKey: "access", SecretKey: "secret", Bucket: "images"},
+				MaxInterestsPerUser: 10,
+				AccountPurgeGrace:   720 * time.Hour,
+				SeenRecycleAfter:    720 * time.Hour,
+			},
+		},
+		{
+			name:      "Fail - explicit config file missing",
+			args:      []string{"-config", filepath.Join(dir, "missing.env")},
+			wantError: true,
+		},
+		{
+			name:      "Fail - missing values are all reported",
+			args:      []string{"-config", os.DevNull},
+			wantError: true,
+			missing:   []string{"DB_KEY", "MINIO_ENDPOINT", "MINIO_ACCESSKEY", "MINIO_SECRETKEY", "BUCKET_NAME"},
+		},
+		{
+			name: "Success - healthcheck mode needs no credentials",
+			args: []string{"-config", os.DevNull, "-healthcheck"},
+			expected: config.Config{
+				Port:                "8081",
+				ShutdownTimeout:     15 * time.Second,
+				HealthCheckInterval: 10 * time.Second,
+				Logging:             config.Logging{Format: config.LogFormatText},
+				Tracing:             config.Tracing{Exporter: "none", Endpoint: "localhost:4317", Insecure: true},
+				HealthCheck:         true,
+				Redis:               config.Redis{Addr: "redis-service:6379"},
+				MaxInterestsPerUser: 10,
+				AccountPurgeGrace:   720 * time.Hour,
+				SeenRecycleAfter:    720 * time.Hour,
+			},
+		},
+		{
+			name: "Success - interest limit from env",
+			args: []string{"-config", envFile},
+			env:  map[string]string{"MAX_INTERESTS": "5"},
+			expected: config.Config{
+				Port:                "8081",
+				DBKey:               "file-db",
+				ShutdownTimeout:     15 * time.Second,
+				HealthCheckInterval: 10 * time.Second,
+				Logging:             config.Logging{Format: config.LogFormatText},
+				Tracing:             config.Tracing{Exporter: "none", Endpoint: "localhost:4317", Insecure: true},
+				Redis:               config.Redis{Addr: "file-redis:6379"},
+				Minio:               config.Minio{Endpoint: "minio:9000", AccessKey: "access", SecretKey: "secret", Bucket: "images"},
+				MaxInterestsPerUser: 5,
+				AccountPurgeGrace:   720 * time.Hour,
+				SeenRecycleAfter:    720 * time.Hour,
+			},
+		},
+		{
+			name:      "Fail - interest limit is not positive",
+			args:      []string{"-config", envFile},
+			env:       map[string]string{"MAX_INTERESTS": "0"},
+			wantError: true,
+		},
+		{
+			name:      "Fail - purge grace is not a duration",
+			args:      []string{"-config", envFile},
+			env:       map[string]string{"ACCOUNT_PURGE_GRACE": "30 days"},
+			wantError: true,
+		},
+		{
+			name: "Success - recycling from env",
+			args: []string{"-config", envFile},
+			env:  map[string]string{"SEEN_RECYCLE_AFTER": "168h", "LIKED_RECYCLE_AFTER": "2160h", "RESET_SEEN_ON_PREFERENCE_CHANGE": "true"},
+			expected: config.Config{
+				Port:                "8081",
+				DBKey:               "file-db",
+				ShutdownTimeout:     15 * time.Second,
+				HealthCheckInterval: 10 * time.Second,
+				Logging:             config.Logging{Format: config.LogFormatText},
+				Tracing:             config.Tracing{Exporter: "none", Endpoint: "localhost:4317", Insecure: true},
+				Redis:               config.Redis{Addr: "file-redis:6379"},
+				Minio:               config.Minio{Endpoint: "minio:9000", AccessKey: "access", SecretKey: "secret", Bucket: "images"},
+				MaxInterestsPerUser: 10,
+				AccountPurgeGrace:   720 * time.Hour,
+				SeenRecycleAfter:    168 * time.Hour,
+				LikedRecycleAfter:   2160 * time.Hour,
+
+				ResetSeenOnPreferenceChange: true,
+			},
+		},
+		{
+			name:      "Fail - seen profiles are never recycled",
+			args:      []string{"-config", envFile},
+			env:       map[string]string{"SEEN_RECYCLE_AFTER": "0s"},
+			wantError: true,
+		},
+		{
+			name:      "Fail - invalid port",
+			args:      []string{"-config", envFile, "-port", "http"},
+			wantError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, k := range []string{"GRPC_PORT", "SHUTDOWN_TIMEOUT", "HEALTH_INTERVAL", "METRICS_ADDR", "MAX_INTERESTS", "ACCOUNT_PURGE_GRACE", "SEEN_RECYCLE_AFTER", "LIKED_RECYCLE_AFTER", "RESET_SEEN_ON_PREFERENCE_CHANGE", "LOG_LEVEL", "LOG_FORMAT", "TRACING_EXPORTER", "OTLP_ENDPOINT", "OTLP_INSECURE", "TRACING_FILE", "DB_KEY", "REDIS_ADDR", "REDIS_PASSWORD", "REDIS_DB", "MINIO_ENDPOINT", "MINIO_ACCESSKEY", "MINIO_SECRETKEY", "BUCKET_NAME", "MINIO_USE_SSL"} {
+				t.Setenv(k, "")
+				os.Unsetenv(k)
+			}
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := config.Load(test.args)
+			if test.wantError {
+				assert.Error(t, err)
+				if test.missing != nil {
+					var missing *config.MissingError
+					assert.True(t, errors.As(err, &missing))
+					assert.Equal(t, test.missing, missing.Keys)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, cfg)
+			}
+		})
+	}
+}
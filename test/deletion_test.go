@@ -0,0 +1,262 @@
+package userServiceTest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	mock_usecases "github.com/akshaybt001/DatingApp_UserService/internal/usecases/mockUsecase"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/go-redis/redis"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestAccountDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+	userService.SetAccountPurgeGrace(48 * time.Hour)
+
+	testUUID := uuid.New()
+	hashed, err := helper.HashPassword("secret")
+	assert.NoError(t, err)
+	mockAdapters.EXPECT().GetUserById(testUUID.String()).Return(entities.User{ID: testUUID, Name: "valid", Password: hashed}, nil).Times(2)
+
+	_, err = userService.RequestAccountDeletion(context.Background(), &pb.AccountDeletionRequest{UserId: testUUID.String(), CurrentPassword: "wrong"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	deletedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockAdapters.EXPECT().SoftDeleteUser(testUUID.String()).Return(deletedAt, nil)
+	res, err := userService.RequestAccountDeletion(context.Background(), &pb.AccountDeletionRequest{UserId: testUUID.String(), CurrentPassword: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-03-03T10:00:00Z", res.PurgeAfter)
+}
+
+func TestUserLoginDeletedAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	hashed, err := helper.HashPassword("secret")
+	assert.NoError(t, err)
+	deletedAt := time.Now()
+	mockAdapters.EXPECT().GetUserByEmail("valid@example.com").Return(entities.User{
+		ID:        uuid.New(),
+		Name:      "valid",
+		Email:     "valid@example.com",
+		Password:  hashed,
+		DeletedAt: &deletedAt,
+	}, nil)
+	_, err = userService.UserLogin(context.Background(), &pb.LoginRequest{Email: "valid@example.com", Password: "secret"})
+	assert.Error(t, err)
+}
+
+func TestPurgeDeletedAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	usecase := mock_usecases.NewMockUsecases(ctrl)
+	userService := service.NewUserService(mockAdapters, usecase, nil)
+
+	failing, purged := uuid.NewString(), uuid.NewString()
+	mockAdapters.EXPECT().GetUsersToPurge(gomock.Any()).DoAndReturn(func(before time.Time) ([]string, error) {
+		assert.WithinDuration(t, time.Now().Add(-service.DefaultAccountPurgeGrace), before, time.Minute)
+		return []string{failing, purged}, nil
+	})
+	mockAdapters.EXPECT().FetchUserImages(failing).Return([]string{"http://minio/bucket/images/a.jpg"}, nil)
+	usecase.EXPECT().DeleteImages(gomock.Any(), []string{"http://minio/bucket/images/a.jpg"}).Return(fmt.Errorf("minio down"))
+	mockAdapters.EXPECT().FetchUserImages(purged).Return(nil, nil)
+	usecase.EXPECT().DeleteImages(gomock.Any(), nil).Return(nil)
+	mockAdapters.EXPECT().GetProfileIdByUserId(purged).Return(uuid.NewString(), nil)
+	mockAdapters.EXPECT().PurgeUser(purged).Return(nil)
+
+	n, err := userService.PurgeDeletedAccounts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n, "rows are kept while their images could not be removed")
+}
+
+func TestPurgeDeletedAccountsRedis(t *testing.T) {
+	redisClient := testRedis(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	usecase := mock_usecases.NewMockUsecases(ctrl)
+	userService := service.NewUserService(mockAdapters, usecase, redisClient)
+
+	purged, purgedProfile := uuid.NewString(), uuid.NewString()
+	viewerProfile, kept := uuid.NewString(), uuid.NewString()
+	keys := []string{
+		"seen_users:" + purgedProfile, "swipe_history:" + purgedProfile,
+		"seen_users:" + viewerProfile, "swipe_history:" + viewerProfile,
+		"seen_by:" + purged,
+	}
+	t.Cleanup(func() {
+		redisClient.Del(keys...)
+	})
+	now := float64(time.Now().Unix())
+	assert.NoError(t, redisClient.ZAdd(keys[0], redis.Z{Score: now, Member: kept}).Err())
+	assert.NoError(t, redisClient.RPush(keys[1], kept).Err())
+	assert.NoError(t, redisClient.ZAdd(keys[2], redis.Z{Score: now, Member: purged}, redis.Z{Score: now, Member: kept}).Err())
+	assert.NoError(t, redisClient.LPush(keys[3], purged, kept, purged).Err())
+	assert.NoError(t, redisClient.SAdd(keys[4], viewerProfile).Err())
+
+	mockAdapters.EXPECT().GetUsersToPurge(gomock.Any()).Return([]string{purged}, nil)
+	mockAdapters.EXPECT().FetchUserImages(purged).Return(nil, nil)
+	usecase.EXPECT().DeleteImages(gomock.Any(), nil).Return(nil)
+	mockAdapters.EXPECT().GetProfileIdByUserId(purged).Return(purgedProfile, nil)
+	mockAdapters.EXPECT().PurgeUser(purged).Return(nil)
+
+	n, err := userService.PurgeDeletedAccounts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	exists, err := redisClient.Exists(keys[0], keys[1]).Result()
+	assert.NoError(t, err)
+	assert.Zero(t, exists, "the keys of the purged profile are removed")
+	seen, err := redisClient.ZRange(keys[2], 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{kept}, seen, "the purged user leaves other profiles' seen users")
+	history, err := redisClient.LRange(keys[3], 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{kept}, history, "the purged user leaves other profiles' swipe histories")
+	exists, err = redisClient.Exists(keys[4]).Result()
+	assert.NoError(t, err)
+	assert.Zero(t, exists, "the index of the purged user is removed")
+}
+
+type exportStream struct {
+	pb.UserService_ExportMyDataServer
+	sent []*pb.DataExportChunk
+}
+
+func (s *exportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *exportStream) Send(chunk *pb.DataExportChunk) error {
+	s.sent = append(s.sent, chunk)
+	return nil
+}
+
+func TestExportMyData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.NewString()
+	bio := strings.Repeat("a", 100<<10)
+	mockAdapters.EXPECT().ExportUserData(testUUID).Return(helperstruct.DataExport{
+		"account": {{"id": testUUID, "name": "valid"}},
+		"profile": {{"bio": bio}},
+	}, nil)
+	srv := &exportStream{}
+	assert.NoError(t, userService.ExportMyData(&pb.GetUserById{Id: testUUID}, srv))
+	assert.Len(t, srv.sent, 2, "large archives are split into chunks")
+
+	var archive bytes.Buffer
+	for _, chunk := range srv.sent {
+		archive.Write(chunk.Data)
+	}
+	var got struct {
+		UserId string                  `json:"user_id"`
+		Data   helperstruct.DataExport `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(archive.Bytes(), &got))
+	assert.Equal(t, testUUID, got.UserId)
+	assert.Equal(t, bio, got.Data["profile"][0]["bio"])
+
+	mockAdapters.EXPECT().ExportUserData(testUUID).Return(helperstruct.DataExport{"account": {}}, nil)
+	err := userService.ExportMyData(&pb.GetUserById{Id: testUUID}, &exportStream{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAccountDeletionPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	gender := seedGender(t, tx)
+	city := "city-" + uuid.NewString()
+	_, viewer := seedUser(t, tx, seedProfile{Name: "viewer", Age: 27, GenderId: gender, City: city})
+	deleted, deletedProfile := seedUser(t, tx, seedProfile{Name: "deleted", Age: 27, GenderId: gender, City: city})
+	seedPreference(t, tx, deletedProfile, entities.Preference{MinAge: 18, MaxAge: 40, GenderId: gender, DesireCity: city})
+	_, err := repo.UploadProfileImage("http://minio/bucket/images/deleted.jpg", deletedProfile)
+	assert.NoError(t, err)
+
+	data, err := repo.ExportUserData(deleted)
+	assert.NoError(t, err)
+	if assert.Len(t, data["account"], 1) {
+		assert.NotContains(t, data["account"][0], "password")
+	}
+	assert.Len(t, data["images"], 1)
+	assert.Len(t, data["preferences"], 1)
+
+	assert.Empty(t, mustGetUsersToPurge(t, repo, deleted), "only deleted accounts are purged")
+	_, err = repo.SoftDeleteUser(deleted)
+	assert.NoError(t, err)
+	exists, err := repo.IsUserExist(deleted)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	users, err := repo.FetchCandidates(helperstruct.CandidateQuery{ProfileId: viewer, MinAge: 27, MaxAge: 27, City: city})
+	assert.NoError(t, err)
+	assert.Empty(t, users, "deleted accounts are hidden from discovery")
+
+	assert.Equal(t, []string{deleted}, mustGetUsersToPurge(t, repo, deleted))
+	images, err := repo.FetchUserImages(deleted)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://minio/bucket/images/deleted.jpg"}, images)
+	assert.NoError(t, repo.PurgeUser(deleted))
+	for _, query := range []string{
+		`SELECT COUNT(*) FROM profiles WHERE id=?`,
+		`SELECT COUNT(*) FROM addresses WHERE profile_id=?`,
+		`SELECT COUNT(*) FROM preferences WHERE profile_id=?`,
+		`SELECT COUNT(*) FROM user_genders WHERE profile_id=?`,
+		`SELECT COUNT(*) FROM images WHERE profile_id=?`,
+	} {
+		var count int
+		if err := tx.Raw(query, deletedProfile).Scan(&count).Error; err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		assert.Zero(t, count, query)
+	}
+	data, err = repo.ExportUserData(deleted)
+	assert.NoError(t, err)
+	assert.Empty(t, data["account"])
+}
+
+// mustGetUsersToPurge returns userId when GetUsersToPurge lists it as due
+// for purging now, and nothing otherwise.
+func mustGetUsersToPurge(t *testing.T, repo *adapters.UserAdapter, userId string) []string {
+	t.Helper()
+	ids, err := repo.GetUsersToPurge(time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("fetching accounts to purge: %v", err)
+	}
+	var res []string
+	for _, id := range ids {
+		if id == userId {
+			res = append(res, id)
+		}
+	}
+	return res
+}
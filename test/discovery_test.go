@@ -0,0 +1,244 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestSetDiscoveryMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	mockAdapters.EXPECT().GetUserById(testUUID.String()).Return(entities.User{ID: testUUID}, nil)
+	mockAdapters.EXPECT().SetDiscoveryMode(testUUID.String(), entities.DiscoveryIncognito).Return(nil)
+	res, err := userService.SetDiscoveryMode(context.Background(), &pb.DiscoveryModeRequest{UserId: testUUID.String(), Mode: pb.DiscoveryMode_INCOGNITO})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.DiscoveryMode_INCOGNITO, res.Mode)
+
+	mockAdapters.EXPECT().GetUserById(testUUID.String()).Return(entities.User{ID: testUUID, DiscoveryMode: entities.DiscoveryPaused}, nil)
+	res, err = userService.GetDiscoveryMode(context.Background(), &pb.GetUserById{Id: testUUID.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.DiscoveryMode_PAUSED, res.Mode)
+
+	mockAdapters.EXPECT().GetUserById(testUUID.String()).Return(entities.User{}, nil)
+	_, err = userService.SetDiscoveryMode(context.Background(), &pb.DiscoveryModeRequest{UserId: testUUID.String(), Mode: pb.DiscoveryMode_PAUSED})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRecordLikeUnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	userId, likedId := uuid.NewString(), uuid.NewString()
+	mockAdapters.EXPECT().RecordLike(userId, likedId).Return(gorm.ErrForeignKeyViolated)
+	_, err := userService.RecordLike(context.Background(), &pb.UserLikeRequest{UserId: userId, LikedId: likedId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+type likeStream struct {
+	pb.UserService_ImportLikesServer
+	likes []*pb.UserLikeRequest
+	res   *pb.ImportLikesResponse
+}
+
+func (s *likeStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *likeStream) Recv() (*pb.UserLikeRequest, error) {
+	if len(s.likes) == 0 {
+		return nil, io.EOF
+	}
+	like := s.likes[0]
+	s.likes = s.likes[1:]
+	return like, nil
+}
+
+func (s *likeStream) SendAndClose(res *pb.ImportLikesResponse) error {
+	s.res = res
+	return nil
+}
+
+func TestImportLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	userId, likedId := uuid.New(), uuid.New()
+	likedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockAdapters.EXPECT().ImportLikes(gomock.Any()).DoAndReturn(func(likes []entities.UserLike) (int, error) {
+		if assert.Len(t, likes, 2) {
+			assert.Equal(t, entities.UserLike{UserId: userId, LikedId: likedId, CreatedAt: likedAt}, likes[0])
+			assert.WithinDuration(t, time.Now(), likes[1].CreatedAt, time.Minute, "likes without a time are made now")
+		}
+		return 1, nil
+	})
+	stream := &likeStream{likes: []*pb.UserLikeRequest{
+		{UserId: userId.String(), LikedId: likedId.String(), LikedAt: likedAt.Format(time.RFC3339)},
+		{UserId: likedId.String(), LikedId: userId.String()},
+	}}
+	assert.NoError(t, userService.ImportLikes(stream))
+	if assert.NotNil(t, stream.res) {
+		assert.Equal(t, int32(1), stream.res.Imported)
+		assert.Equal(t, int32(1), stream.res.Skipped)
+	}
+
+	stream = &likeStream{likes: []*pb.UserLikeRequest{{UserId: "not-a-uuid", LikedId: likedId.String()}}}
+	assert.Equal(t, codes.InvalidArgument, status.Code(userService.ImportLikes(stream)))
+}
+
+// discoveryUsers seeds a viewer and visible, paused and incognito
+// candidates who all match each other's preferences.
+func discoveryUsers(t *testing.T, tx *gorm.DB) (viewer, viewerProfile string, candidates map[string]string) {
+	t.Helper()
+	repo := adapters.NewUserAdapter(tx)
+	gender := seedGender(t, tx)
+	city := "city-" + uuid.NewString()
+	pref := entities.Preference{MinAge: 18, MaxAge: 40, GenderId: gender, DesireCity: city}
+	viewer, viewerProfile = seedUser(t, tx, seedProfile{Name: "viewer", Age: 27, GenderId: gender, City: city})
+	seedPreference(t, tx, viewerProfile, pref)
+	candidates = make(map[string]string)
+	for name, mode := range map[string]int{
+		"visible":   entities.DiscoveryVisible,
+		"paused":    entities.DiscoveryPaused,
+		"incognito": entities.DiscoveryIncognito,
+	} {
+		id, profile := seedUser(t, tx, seedProfile{Name: name, Age: 27, GenderId: gender, City: city})
+		seedPreference(t, tx, profile, pref)
+		if err := repo.SetDiscoveryMode(id, mode); err != nil {
+			t.Fatalf("setting discovery mode: %v", err)
+		}
+		candidates[name] = id
+	}
+	return viewer, viewerProfile, candidates
+}
+
+func TestDiscoveryModeCandidatesPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	viewer, viewerProfile, candidates := discoveryUsers(t, tx)
+	fetch := func() []string {
+		users, err := repo.FetchCandidates(helperstruct.CandidateQuery{ProfileId: viewerProfile, MinAge: 18, MaxAge: 40, Reciprocal: true})
+		assert.NoError(t, err)
+		var names []string
+		for _, u := range users {
+			for name, id := range candidates {
+				if u.Id == id {
+					names = append(names, name)
+				}
+			}
+		}
+		return names
+	}
+	assert.Equal(t, []string{"visible"}, fetch())
+
+	assert.NoError(t, repo.RecordLike(candidates["incognito"], viewer))
+	assert.NoError(t, repo.RecordLike(candidates["incognito"], viewer), "likes are recorded once")
+	assert.ElementsMatch(t, []string{"visible", "incognito"}, fetch(), "incognito users are shown to users they liked")
+
+	assert.NoError(t, repo.RemoveLike(candidates["incognito"], viewer))
+	assert.NoError(t, repo.RecordLike(viewer, candidates["paused"]))
+	assert.Equal(t, []string{"visible"}, fetch(), "paused users are hidden even from users they liked")
+}
+
+func TestIncognitoLikeRPCsPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+	userService := service.NewUserService(repo, nil, nil)
+
+	viewer, viewerProfile, candidates := discoveryUsers(t, tx)
+	incognito := func() bool {
+		users, err := repo.FetchCandidates(helperstruct.CandidateQuery{ProfileId: viewerProfile, MinAge: 18, MaxAge: 40, Reciprocal: true})
+		assert.NoError(t, err)
+		for _, u := range users {
+			if u.Id == candidates["incognito"] {
+				return true
+			}
+		}
+		return false
+	}
+	like := &pb.UserLikeRequest{UserId: candidates["incognito"], LikedId: viewer}
+	assert.False(t, incognito())
+
+	_, err := userService.RecordLike(context.Background(), like)
+	assert.NoError(t, err)
+	assert.True(t, incognito(), "a like from the match service shows the incognito user")
+	_, err = userService.RemoveLike(context.Background(), like)
+	assert.NoError(t, err)
+	assert.False(t, incognito(), "an unlike hides the incognito user again")
+
+	// Likes made before the match service recorded them are imported.
+	stream := &likeStream{likes: []*pb.UserLikeRequest{
+		{UserId: candidates["incognito"], LikedId: viewer, LikedAt: time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+		{UserId: candidates["incognito"], LikedId: viewer},
+		{UserId: uuid.NewString(), LikedId: viewer},
+	}}
+	assert.NoError(t, userService.ImportLikes(stream))
+	if assert.NotNil(t, stream.res) {
+		assert.Equal(t, int32(1), stream.res.Imported)
+		assert.Equal(t, int32(2), stream.res.Skipped, "repeated likes and unknown users are skipped")
+	}
+	assert.True(t, incognito(), "an imported like shows the incognito user")
+}
+
+func TestDiscoveryModeSeenSetPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	viewer, viewerProfile, candidates := discoveryUsers(t, tx)
+	redisClient := testRedis(t)
+	seenKey := fmt.Sprintf("seen_users:%s", viewerProfile)
+	t.Cleanup(func() {
+		redisClient.Del(seenKey, fmt.Sprintf("swipe_history:%s", viewerProfile))
+	})
+	userService := service.NewUserService(repo, nil, redisClient)
+	next := func() string {
+		res, err := userService.HomePage(context.Background(), &pb.GetUserById{Id: viewer})
+		if err != nil {
+			return ""
+		}
+		return res.Id
+	}
+
+	assert.Equal(t, candidates["visible"], next())
+	assert.Empty(t, next(), "hidden users are not recommended")
+	seen, err := redisClient.ZRange(seenKey, 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{candidates["visible"]}, seen, "hidden users are not marked as seen")
+
+	assert.NoError(t, repo.RecordLike(candidates["incognito"], viewer))
+	assert.Equal(t, candidates["incognito"], next())
+
+	assert.NoError(t, repo.SetDiscoveryMode(candidates["paused"], entities.DiscoveryVisible))
+	assert.Equal(t, candidates["paused"], next(), "unpaused users can be recommended again")
+
+	assert.NoError(t, repo.SetDiscoveryMode(candidates["visible"], entities.DiscoveryPaused))
+	assert.NoError(t, repo.SetDiscoveryMode(candidates["visible"], entities.DiscoveryVisible))
+	assert.Empty(t, next(), "pausing does not clear the viewer's seen users")
+}
@@ -0,0 +1,207 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type genderStream struct {
+	pb.UserService_GetAllGenderUserServer
+	sent []*pb.GenderResponse
+}
+
+func (s *genderStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *genderStream) Send(res *pb.GenderResponse) error {
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+func TestGetAllGenderUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	profileTestUUID := uuid.New()
+
+	mockAdapters.EXPECT().GetProfileIdByUserId(testUUID.String()).Return(profileTestUUID.String(), nil)
+	mockAdapters.EXPECT().UserGetAllGender(profileTestUUID.String()).Return([]helperstruct.GenderHelper{
+		{GenderId: 1, GenderName: "woman"},
+		{GenderId: 3, GenderName: "non-binary"},
+	}, nil)
+
+	srv := &genderStream{}
+	err := userService.GetAllGenderUser(&pb.GetUserById{Id: testUUID.String()}, srv)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.GenderResponse{
+		{Id: 1, Gender: "woman"},
+		{Id: 3, Gender: "non-binary"},
+	}, srv.sent)
+}
+
+func TestRemoveGenderUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	profileTestUUID := uuid.New()
+	added := entities.UserGenders{Id: 7, ProfileId: profileTestUUID, GenderId: 2}
+
+	tests := []struct {
+		name                  string
+		mockGetUserGenderById func(string, int) (entities.UserGenders, error)
+		mockUserRemoveGender  func(entities.UserGenders) error
+		expectedError         bool
+	}{
+		{
+			name: "Success",
+			mockGetUserGenderById: func(s string, i int) (entities.UserGenders, error) {
+				return added, nil
+			},
+			mockUserRemoveGender: func(g entities.UserGenders) error {
+				return nil
+			},
+			expectedError: false,
+		},
+		{
+			name: "Fail - gender not added",
+			mockGetUserGenderById: func(s string, i int) (entities.UserGenders, error) {
+				return entities.UserGenders{}, nil
+			},
+			expectedError: true,
+		},
+		{
+			name: "Fail - UserRemoveGender error",
+			mockGetUserGenderById: func(s string, i int) (entities.UserGenders, error) {
+				return added, nil
+			},
+			mockUserRemoveGender: func(g entities.UserGenders) error {
+				return fmt.Errorf("delete failed")
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAdapters.EXPECT().GetProfileIdByUserId(testUUID.String()).Return(profileTestUUID.String(), nil)
+			mockAdapters.EXPECT().GetUserGenderById(profileTestUUID.String(), 2).DoAndReturn(test.mockGetUserGenderById)
+			if test.mockUserRemoveGender != nil {
+				mockAdapters.EXPECT().UserRemoveGender(added).DoAndReturn(test.mockUserRemoveGender)
+			}
+			_, err := userService.RemoveGenderUser(context.Background(), &pb.UpdateGenderRequest{UserId: testUUID.String(), GenderId: 2})
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFetchCandidatesGenderSets(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	woman, man, nonBinary := seedGender(t, tx), seedGender(t, tx), seedGender(t, tx)
+	city := "city-" + uuid.NewString()
+	_, viewer := seedUser(t, tx, seedProfile{Name: "viewer", Age: 28, GenderIds: []int{man, nonBinary}, City: city})
+	seedPreference(t, tx, viewer, entities.Preference{MinAge: 25, MaxAge: 30, GenderIds: []int{woman, nonBinary}, DesireCity: city})
+
+	for _, c := range []struct {
+		name    string
+		genders []int
+		wants   []int
+	}{
+		{name: "woman-wants-men", genders: []int{woman}, wants: []int{man}},
+		{name: "both-wants-nonbinary", genders: []int{woman, nonBinary}, wants: []int{nonBinary}},
+		{name: "nonbinary-wants-women", genders: []int{nonBinary}, wants: []int{woman}},
+		{name: "man-wants-men", genders: []int{man}, wants: []int{man}},
+	} {
+		_, profile := seedUser(t, tx, seedProfile{Name: c.name, Age: 27, GenderIds: c.genders, City: city})
+		seedPreference(t, tx, profile, entities.Preference{MinAge: 25, MaxAge: 30, GenderIds: c.wants, DesireCity: city})
+	}
+
+	pref, err := repo.FetchPreference(viewer)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{woman, nonBinary}, pref.Genders)
+
+	q := helperstruct.CandidateQuery{ProfileId: viewer, MinAge: 25, MaxAge: 30, GenderIds: pref.Genders, City: city}
+	users, err := repo.FetchCandidates(q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"both-wants-nonbinary", "nonbinary-wants-women", "woman-wants-men"}, candidateNames(users), "each candidate is listed once")
+
+	q.Reciprocal = true
+	users, err = repo.FetchCandidates(q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"both-wants-nonbinary", "woman-wants-men"}, candidateNames(users))
+
+	assert.NoError(t, repo.UserRemoveGender(entities.UserGenders{ProfileId: uuid.MustParse(viewer), GenderId: man}))
+	users, err = repo.FetchCandidates(q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"both-wants-nonbinary"}, candidateNames(users))
+}
+
+func TestUserAddPreferenceUnknownGender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID, profileUUID := uuid.NewString(), uuid.NewString()
+	mockAdapters.EXPECT().GetProfileIdByUserId(testUUID).Return(profileUUID, nil)
+	mockAdapters.EXPECT().GetPreferenceByProfileId(profileUUID).Return(entities.Preference{}, nil)
+	mockAdapters.EXPECT().UserAddPreference(gomock.Any()).Return(adapters.ErrUnknownGender)
+	_, err := userService.UserAddPreference(context.Background(), &pb.PreferenceRequest{UserId: testUUID, Minage: 25, Maxage: 30, Genders: []int32{1, 2}, Desirecity: "kochi"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestPreferenceGendersPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	woman, man, deleted := seedGender(t, tx), seedGender(t, tx), seedGender(t, tx)
+	if err := tx.Exec(`UPDATE genders SET is_deleted=true WHERE id=?`, deleted).Error; err != nil {
+		t.Fatalf("deleting gender: %v", err)
+	}
+	_, profile := seedUser(t, tx, seedProfile{Name: "viewer", Age: 28, GenderId: man, City: "kochi"})
+	pref := entities.Preference{MinAge: 25, MaxAge: 30, GenderId: woman, DesireCity: "kochi", ProfileId: uuid.MustParse(profile)}
+
+	pref.GenderIds = []int{woman, deleted}
+	assert.ErrorIs(t, repo.UserAddPreference(pref), adapters.ErrUnknownGender, "deleted genders are rejected")
+	pref.GenderIds = []int{woman, deleted + 1000}
+	assert.ErrorIs(t, repo.UserAddPreference(pref), adapters.ErrUnknownGender, "unknown genders are rejected")
+	stored, err := repo.FetchPreference(profile)
+	assert.NoError(t, err)
+	assert.Empty(t, stored.DesireCity, "a rejected preference is not stored")
+
+	pref.GenderIds = []int{woman, man, woman}
+	assert.NoError(t, repo.UserAddPreference(pref), "repeated ids count once")
+	pref.GenderIds = []int{woman, deleted}
+	assert.ErrorIs(t, repo.UserEditPreference(pref), adapters.ErrUnknownGender)
+	stored, err = repo.FetchPreference(profile)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{woman, man}, stored.Genders, "a rejected edit keeps the accepted genders")
+}
@@ -0,0 +1,48 @@
+package userServiceTest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/healthcheck"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestHealthChecker(t *testing.T) {
+	var healthy atomic.Bool
+	checker := healthcheck.NewChecker(20*time.Millisecond, map[string]healthcheck.Check{
+		"dependency": func(ctx context.Context) error {
+			if !healthy.Load() {
+				return errors.New("dependency is down")
+			}
+			return nil
+		},
+	})
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	checker.Register(server)
+	go server.Serve(listener)
+	defer server.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Run(ctx)
+
+	addr := listener.Addr().String()
+	assert.Error(t, healthcheck.Probe(addr, time.Second))
+
+	healthy.Store(true)
+	assert.Eventually(t, func() bool {
+		return healthcheck.Probe(addr, time.Second) == nil
+	}, time.Second, 10*time.Millisecond)
+
+	checker.Shutdown()
+	assert.Error(t, healthcheck.Probe(addr, time.Second))
+}
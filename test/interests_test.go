@@ -0,0 +1,243 @@
+package userServiceTest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type interestStream struct {
+	pb.UserService_GetAllInterestServer
+	sent []*pb.InterestResponse
+}
+
+func (s *interestStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *interestStream) Send(res *pb.InterestResponse) error {
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+func TestGetAllInterest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	mockAdapters.EXPECT().AdminGetAllInterest(2, nil).Return([]helperstruct.InterestHelper{
+		{InterestId: 4, InterestName: "football", CategoryId: 2, CategoryName: "Sports"},
+		{InterestId: 1, InterestName: "tennis", CategoryId: 2, CategoryName: "Sports"},
+	}, nil)
+
+	srv := &interestStream{}
+	err := userService.GetAllInterest(&pb.InterestFilter{CategoryId: 2}, srv)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.InterestResponse{
+		{Id: 4, Interest: "football", CategoryId: 2, Category: "Sports"},
+		{Id: 1, Interest: "tennis", CategoryId: 2, Category: "Sports"},
+	}, srv.sent)
+}
+
+func TestAdminAddInterestWithCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	mockAdapters.EXPECT().GetInterestCategoryById(2).Return(entities.InterestCategory{Id: 2, Name: "Sports"}, nil)
+	mockAdapters.EXPECT().AdminAddInterest(gomock.Any()).DoAndReturn(func(i entities.Interests) error {
+		if assert.NotNil(t, i.CategoryId) {
+			assert.Equal(t, 2, *i.CategoryId)
+		}
+		return nil
+	})
+	_, err := userService.AdminAddInterest(context.Background(), &pb.AddInterestRequest{Interest: "football", CategoryId: 2})
+	assert.NoError(t, err)
+
+	mockAdapters.EXPECT().GetInterestCategoryById(9).Return(entities.InterestCategory{}, nil)
+	_, err = userService.AdminAddInterest(context.Background(), &pb.AddInterestRequest{Interest: "chess", CategoryId: 9})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAddInterestUserLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID, profileUUID := uuid.NewString(), uuid.NewString()
+	mockAdapters.EXPECT().GetInterestById(2).Return(helperstruct.InterestHelper{InterestId: 2}, nil)
+	mockAdapters.EXPECT().GetProfileIdByUserId(testUUID).Return(profileUUID, nil)
+	mockAdapters.EXPECT().UserAddInterestWithin(gomock.Any(), service.DefaultMaxInterests).Return(adapters.ErrTooManyInterests)
+	_, err := userService.AddInterestUser(context.Background(), &pb.DeleteInterestRequest{UserId: testUUID, InterestId: 2})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAddInterestUserTwice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID, profileUUID := uuid.NewString(), uuid.NewString()
+	mockAdapters.EXPECT().GetInterestById(2).Return(helperstruct.InterestHelper{InterestId: 2}, nil)
+	mockAdapters.EXPECT().GetProfileIdByUserId(testUUID).Return(profileUUID, nil)
+	mockAdapters.EXPECT().UserAddInterestWithin(gomock.Any(), service.DefaultMaxInterests).Return(gorm.ErrDuplicatedKey)
+	_, err := userService.AddInterestUser(context.Background(), &pb.DeleteInterestRequest{UserId: testUUID, InterestId: 2})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestSetUserInterests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+	userService.SetMaxInterests(3)
+
+	testUUID := uuid.New()
+	profileTestUUID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		interestIds          []int32
+		mockSetUserInterests func(string, []int) error
+		wantCode             codes.Code
+	}{
+		{
+			name:        "Success - duplicates are ignored",
+			interestIds: []int32{3, 1, 3, 2},
+			mockSetUserInterests: func(profile string, ids []int) error {
+				assert.Equal(t, []int{3, 1, 2}, ids)
+				return nil
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:        "Success - clear all interests",
+			interestIds: nil,
+			mockSetUserInterests: func(profile string, ids []int) error {
+				assert.Empty(t, ids)
+				return nil
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:        "Fail - more than the maximum",
+			interestIds: []int32{1, 2, 3, 4},
+			wantCode:    codes.InvalidArgument,
+		},
+		{
+			name:        "Fail - unknown interest",
+			interestIds: []int32{1, 99},
+			mockSetUserInterests: func(profile string, ids []int) error {
+				return adapters.ErrUnknownInterest
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.mockSetUserInterests != nil {
+				mockAdapters.EXPECT().GetProfileIdByUserId(testUUID.String()).Return(profileTestUUID.String(), nil)
+				mockAdapters.EXPECT().SetUserInterests(profileTestUUID.String(), gomock.Any()).DoAndReturn(test.mockSetUserInterests)
+			}
+			_, err := userService.SetUserInterests(context.Background(), &pb.SetUserInterestsRequest{
+				UserId:      testUUID.String(),
+				InterestIds: test.interestIds,
+			})
+			assert.Equal(t, test.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestInterestCatalogPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	prefix := uuid.NewString()
+	category := func(name string, position int) int {
+		c := entities.InterestCategory{Name: prefix + name, Position: position}
+		assert.NoError(t, repo.AdminAddInterestCategory(c))
+		categories, err := repo.AdminGetAllInterestCategories()
+		assert.NoError(t, err)
+		for _, got := range categories {
+			if got.Name == c.Name {
+				return got.Id
+			}
+		}
+		t.Fatalf("category %s was not added", c.Name)
+		return 0
+	}
+	music, sports := category("music", 2), category("sports", 1)
+	interest := func(name string, categoryId int) int {
+		i := entities.Interests{Interest: prefix + name, CategoryId: &categoryId}
+		assert.NoError(t, repo.AdminAddInterest(i))
+		var id int
+		if err := tx.Raw(`SELECT id FROM interests WHERE interest=?`, i.Interest).Scan(&id).Error; err != nil {
+			t.Fatalf("reading interest: %v", err)
+		}
+		return id
+	}
+	jazz, tennis, football := interest("jazz", music), interest("tennis", sports), interest("football", sports)
+
+	interests, err := repo.AdminGetAllInterest(0, nil)
+	assert.NoError(t, err)
+	var names []string
+	for _, i := range interests {
+		if i.CategoryId == music || i.CategoryId == sports {
+			names = append(names, i.InterestName[len(prefix):])
+		}
+	}
+	assert.Equal(t, []string{"football", "tennis", "jazz"}, names, "sports comes first by position, interests by name")
+
+	interests, err = repo.AdminGetAllInterest(music, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, interests, 1) {
+		assert.Equal(t, jazz, interests[0].InterestId)
+	}
+
+	_, profile := seedUser(t, tx, seedProfile{Name: "user", Age: 27, GenderId: seedGender(t, tx)})
+	assert.NoError(t, repo.SetUserInterests(profile, []int{jazz, tennis}))
+	assert.NoError(t, repo.SetUserInterests(profile, []int{football, tennis}))
+	count, err := repo.CountUserInterests(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.ErrorIs(t, repo.SetUserInterests(profile, []int{jazz, -1}), adapters.ErrUnknownInterest)
+	count, err = repo.CountUserInterests(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count, "a failed replace keeps the previous interests")
+
+	link := entities.UserInterests{ProfileId: uuid.MustParse(profile), InterestId: jazz}
+	assert.ErrorIs(t, repo.UserAddInterestWithin(link, 2), adapters.ErrTooManyInterests)
+	assert.NoError(t, repo.UserAddInterestWithin(link, 3))
+	count, err = repo.CountUserInterests(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.ErrorIs(t, repo.UserAddInterestWithin(link, 4), gorm.ErrDuplicatedKey, "an interest is added once")
+
+	// Ids come from the sequence, so rows created either way do not collide.
+	created := entities.Interests{Interest: prefix + "chess"}
+	assert.NoError(t, tx.Create(&created).Error)
+	assert.NotEqual(t, created.Id, interest("go", music))
+}
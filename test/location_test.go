@@ -0,0 +1,209 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	kochi     = helperstruct.Location{Latitude: 9.9312, Longitude: 76.2673}
+	thrissur  = helperstruct.Location{Latitude: 10.5276, Longitude: 76.2144}
+	bengaluru = helperstruct.Location{Latitude: 12.9716, Longitude: 77.5946}
+)
+
+func TestDistanceKm(t *testing.T) {
+	assert.InDelta(t, 0, helper.DistanceKm(kochi, kochi), 0.001)
+	assert.InDelta(t, 66.5, helper.DistanceKm(kochi, thrissur), 1)
+	assert.InDelta(t, 365, helper.DistanceKm(kochi, bengaluru), 5)
+	assert.Equal(t, helper.DistanceKm(kochi, thrissur), helper.DistanceKm(thrissur, kochi))
+	assert.InDelta(t, 20015, helper.DistanceKm(kochi, antipode(kochi)), 1, "antipodal points are half the Earth apart")
+}
+
+// antipode is the point on the opposite side of the Earth from loc.
+func antipode(loc helperstruct.Location) helperstruct.Location {
+	return helperstruct.Location{Latitude: -loc.Latitude, Longitude: loc.Longitude - 180}
+}
+
+func TestBoundingBox(t *testing.T) {
+	minLat, maxLat, minLon, maxLon := helper.BoundingBox(kochi, 100)
+	for _, loc := range []helperstruct.Location{kochi, thrissur} {
+		assert.True(t, loc.Latitude >= minLat && loc.Latitude <= maxLat, "latitude of %v", loc)
+		assert.True(t, loc.Longitude >= minLon && loc.Longitude <= maxLon, "longitude of %v", loc)
+	}
+	assert.False(t, bengaluru.Latitude <= maxLat && bengaluru.Longitude <= maxLon)
+
+	_, _, minLon, maxLon = helper.BoundingBox(helperstruct.Location{Latitude: 89.5, Longitude: 0}, 100)
+	assert.Equal(t, []float64{-180, 180}, []float64{minLon, maxLon}, "a box around a pole covers every longitude")
+	_, _, minLon, maxLon = helper.BoundingBox(helperstruct.Location{Latitude: 0, Longitude: 179.9}, 100)
+	assert.Equal(t, []float64{-180, 180}, []float64{minLon, maxLon}, "a box across the antimeridian covers every longitude")
+}
+
+func TestRoundDistanceKm(t *testing.T) {
+	assert.Equal(t, int32(1), helper.RoundDistanceKm(0.2))
+	assert.Equal(t, int32(66), helper.RoundDistanceKm(66.4))
+	assert.Equal(t, int32(67), helper.RoundDistanceKm(66.5))
+}
+
+func TestUpdateLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	profileTestUUID := uuid.New()
+
+	tests := []struct {
+		name                     string
+		mockGetProfileIdByUserId func(string) (string, error)
+		mockUpdateLocation       func(helperstruct.Location, string) error
+		expectedError            bool
+	}{
+		{
+			name: "Success",
+			mockGetProfileIdByUserId: func(s string) (string, error) {
+				return profileTestUUID.String(), nil
+			},
+			mockUpdateLocation: func(l helperstruct.Location, s string) error {
+				return nil
+			},
+			expectedError: false,
+		},
+		{
+			name: "Fail - GetProfileIdByUserId error",
+			mockGetProfileIdByUserId: func(s string) (string, error) {
+				return "", fmt.Errorf("profile not found")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Fail - UpdateLocation error",
+			mockGetProfileIdByUserId: func(s string) (string, error) {
+				return profileTestUUID.String(), nil
+			},
+			mockUpdateLocation: func(l helperstruct.Location, s string) error {
+				return fmt.Errorf("update failed")
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAdapters.EXPECT().GetProfileIdByUserId(testUUID.String()).DoAndReturn(test.mockGetProfileIdByUserId).Times(1)
+			if test.mockUpdateLocation != nil {
+				mockAdapters.EXPECT().UpdateLocation(kochi, profileTestUUID.String()).DoAndReturn(test.mockUpdateLocation).Times(1)
+			}
+			_, err := userService.UpdateLocation(context.Background(), &pb.UpdateLocationRequest{
+				UserId:    testUUID.String(),
+				Latitude:  kochi.Latitude,
+				Longitude: kochi.Longitude,
+			})
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFetchCandidatesDistance(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	woman, man := seedGender(t, tx), seedGender(t, tx)
+	// Cities are unique so only distance can bring these users together.
+	locate := func(name string, gender int, loc *helperstruct.Location) string {
+		_, profile := seedUser(t, tx, seedProfile{Name: name, Age: 27, GenderId: gender, City: "city-" + uuid.NewString()})
+		if loc != nil {
+			assert.NoError(t, repo.UpdateLocation(*loc, profile))
+		}
+		return profile
+	}
+	viewer := locate("viewer", man, &kochi)
+	assert.Equal(t, kochi, locationOf(t, tx, viewer))
+	seedPreference(t, tx, viewer, entities.Preference{MinAge: 25, MaxAge: 30, GenderId: woman, MaxDistanceKm: 100})
+	wantsNearby := func(profile string, km int) {
+		seedPreference(t, tx, profile, entities.Preference{MinAge: 25, MaxAge: 30, GenderId: man, DesireCity: "nowhere", MaxDistanceKm: km})
+	}
+	wantsNearby(locate("kochi", woman, &kochi), 10)
+	wantsNearby(locate("thrissur", woman, &thrissur), 100)
+	wantsNearby(locate("thrissur-picky", woman, &thrissur), 50)
+	wantsNearby(locate("bengaluru", woman, &bengaluru), 1000)
+	wantsNearby(locate("unlocated", woman, nil), 1000)
+
+	q := helperstruct.CandidateQuery{
+		ProfileId:     viewer,
+		MinAge:        25,
+		MaxAge:        30,
+		GenderIds:     []int{woman},
+		Origin:        &kochi,
+		MaxDistanceKm: 100,
+	}
+	users, err := repo.FetchCandidates(q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kochi", "thrissur", "thrissur-picky"}, candidateNames(users))
+	for _, u := range users {
+		if assert.NotNil(t, u.DistanceKm, u.Name) && u.Name != "kochi" {
+			assert.InDelta(t, helper.DistanceKm(kochi, thrissur), *u.DistanceKm, 0.1)
+		}
+	}
+
+	q.Reciprocal = true
+	users, err = repo.FetchCandidates(q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kochi", "thrissur"}, candidateNames(users), "thrissur-picky only wants people within 50km")
+}
+
+func TestFetchCandidatesAntipodalPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	gender := seedGender(t, tx)
+	locate := func(name string, loc helperstruct.Location) string {
+		_, profile := seedUser(t, tx, seedProfile{Name: name, Age: 27, GenderId: gender, City: "city-" + uuid.NewString()})
+		assert.NoError(t, repo.UpdateLocation(loc, profile))
+		seedPreference(t, tx, profile, entities.Preference{MinAge: 25, MaxAge: 30, GenderId: gender, DesireCity: "nowhere", MaxDistanceKm: 20100})
+		return profile
+	}
+	viewer := locate("viewer", kochi)
+	locate("antipode", antipode(kochi))
+
+	users, err := repo.FetchCandidates(helperstruct.CandidateQuery{
+		ProfileId:     viewer,
+		MinAge:        25,
+		MaxAge:        30,
+		GenderIds:     []int{gender},
+		Origin:        &kochi,
+		MaxDistanceKm: 20100,
+		Reciprocal:    true,
+	})
+	if assert.NoError(t, err, "rounding must not push asin out of range") && assert.Equal(t, []string{"antipode"}, candidateNames(users)) {
+		assert.InDelta(t, 20015, *users[0].DistanceKm, 1)
+	}
+}
+
+// locationOf reads back the stored location of a profile.
+func locationOf(t *testing.T, tx *gorm.DB, profile string) helperstruct.Location {
+	t.Helper()
+	var loc helperstruct.Location
+	if err := tx.Raw(`SELECT latitude, longitude FROM profiles WHERE id=?`, profile).Scan(&loc).Error; err != nil {
+		t.Fatalf("reading location: %v", err)
+	}
+	return loc
+}
@@ -0,0 +1,54 @@
+package userServiceTest
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/config"
+	"github.com/akshaybt001/DatingApp_UserService/logging"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLoggingRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, config.Logging{Level: slog.LevelInfo, Format: config.LogFormatJSON})
+
+	logger.With("email", "valid@gmail.com").Info("signup",
+		"phone", "8888888888",
+		slog.Group("request", "password", "secret", "name", "valid"),
+	)
+	out := buf.String()
+	assert.NotContains(t, out, "valid@gmail.com")
+	assert.NotContains(t, out, "8888888888")
+	assert.NotContains(t, out, "secret")
+	assert.Contains(t, out, `"email":"v***@gmail.com"`)
+	assert.Contains(t, out, `"phone":"********88"`)
+	assert.Contains(t, out, `"name":"valid"`)
+
+	buf.Reset()
+	logger.Debug("hidden below the configured level")
+	assert.Empty(t, buf.String())
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	base := logging.New(&buf, config.Logging{Level: slog.LevelInfo, Format: config.LogFormatText})
+	interceptor := logging.UnaryServerInterceptor(base)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(logging.RequestIDHeader, "req-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUser"}
+
+	_, err := interceptor(ctx, &pb.GetUserById{Id: "user-1"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		logging.FromContext(ctx).InfoContext(ctx, "inside handler")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	for _, line := range []string{"inside handler", "request finished"} {
+		assert.Contains(t, buf.String(), line)
+	}
+	assert.Contains(t, buf.String(), `msg="inside handler" request_id=req-1 method=/user.UserService/GetUser user_id=user-1`)
+}
@@ -0,0 +1,63 @@
+package userServiceTest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_UserService/metrics"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(adapter, nil, nil)
+	hashedPass, err := helper.HashPassword("valid")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		user     entities.User
+		password string
+		outcome  string
+	}{
+		{
+			name:     "Success",
+			user:     entities.User{ID: uuid.New(), Email: "valid@gmail.com", Password: hashedPass},
+			password: "valid",
+			outcome:  metrics.LoginSuccess,
+		},
+		{
+			name:     "Blocked",
+			user:     entities.User{ID: uuid.New(), Email: "valid@gmail.com", Password: hashedPass, IsBlocked: true},
+			password: "valid",
+			outcome:  metrics.LoginBlocked,
+		},
+		{
+			name:     "Wrong password",
+			user:     entities.User{ID: uuid.New(), Email: "valid@gmail.com", Password: hashedPass},
+			password: "invalid",
+			outcome:  metrics.LoginInvalidCredentials,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter.EXPECT().GetUserByEmail(gomock.Any()).Return(test.user, nil).Times(1)
+			before := testutil.ToFloat64(metrics.Logins.WithLabelValues(test.outcome))
+			userService.UserLogin(context.Background(), &pb.LoginRequest{Email: "valid@gmail.com", Password: test.password})
+			assert.Equal(t, before+1, testutil.ToFloat64(metrics.Logins.WithLabelValues(test.outcome)))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package userServiceTest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_UserService/internal/validation"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{phone: "+919876543210", want: "+919876543210"},
+		{phone: "+91 98765-43210", want: "+919876543210"},
+		{phone: "+1 (415) 555.0100", want: "+14155550100"},
+		{phone: "0091 9876543210", want: "+919876543210"},
+		{phone: "9876543210", want: "9876543210"},
+	}
+	for _, test := range tests {
+		t.Run(test.phone, func(t *testing.T) {
+			assert.Equal(t, test.want, helper.NormalizePhone(test.phone))
+		})
+	}
+	assert.Equal(t, "alice@example.com", helper.NormalizeEmail("  Alice@Example.COM "))
+}
+
+func TestInterceptorNormalizes(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/UserSignup"}
+	var got *pb.UserSignupRequest
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = req.(*pb.UserSignupRequest)
+		return nil, nil
+	}
+	_, err := validation.UnaryServerInterceptor(context.Background(), &pb.UserSignupRequest{
+		Name:     "valid",
+		Email:    " Valid@Example.com",
+		Phone:    "+91 98765 43210",
+		Password: "secret",
+	}, info, handler)
+	assert.NoError(t, err)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "valid@example.com", got.Email)
+		assert.Equal(t, "+919876543210", got.Phone)
+	}
+}
+
+func TestUserSignupDuplicateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	// Both checks pass, then a concurrent signup takes the email first.
+	mockAdapters.EXPECT().GetUserByEmail("valid@example.com").Return(entities.User{}, nil)
+	mockAdapters.EXPECT().GetUserByPhone("+919876543210").Return(entities.User{}, nil)
+	mockAdapters.EXPECT().UserSignup(gomock.Any()).Return(entities.User{}, gorm.ErrDuplicatedKey)
+
+	_, err := userService.UserSignup(context.Background(), &pb.UserSignupRequest{
+		Name:     "valid",
+		Email:    "valid@example.com",
+		Phone:    "+919876543210",
+		Password: "secret",
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestEmailUniqueIgnoresCasePostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	email := "case-" + uuid.NewString() + "@example.com"
+	_, err := repo.UserSignup(entities.User{Name: "user", Email: email, Phone: "+1" + uuid.NewString()[:8]})
+	assert.NoError(t, err)
+
+	var replaced int
+	if err := tx.Raw(`SELECT COUNT(*) FROM pg_indexes WHERE indexname = 'idx_users_email'`).Scan(&replaced).Error; err != nil {
+		t.Fatalf("reading indexes: %v", err)
+	}
+	assert.Zero(t, replaced, "the case sensitive email index is dropped")
+
+	found, err := repo.GetUserByEmail("Case-" + email[len("case-"):])
+	assert.NoError(t, err)
+	assert.Equal(t, email, found.Email, "lookups ignore case")
+
+	// This must be the last statement because the failed insert aborts the
+	// transaction.
+	_, err = repo.UserSignup(entities.User{Name: "copy", Email: "CASE-" + email[len("case-"):], Phone: uuid.NewString()})
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,99 @@
+package userServiceTest
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/db"
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// testDB connects to the Postgres database named by TEST_DATABASE_URL and
+// returns a transaction that is rolled back when the test ends. Tests are
+// skipped when the variable is not set.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	database, err := db.InitDB(dsn)
+	if err != nil {
+		t.Fatalf("connecting to test database: %v", err)
+	}
+	tx := database.Session(&gorm.Session{Logger: logger.Discard}).Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return tx
+}
+
+// seedProfile describes a user to insert with seedUser.
+type seedProfile struct {
+	Name      string
+	Age       int
+	GenderId  int
+	GenderIds []int
+	City      string
+	Country   string
+	Blocked   bool
+}
+
+// seedUser inserts a user with a profile, gender and address and returns
+// the user and profile ids. The date of birth is chosen so that the user
+// is exactly Age years old today.
+func seedUser(t *testing.T, tx *gorm.DB, p seedProfile) (string, string) {
+	t.Helper()
+	userId, profileId := uuid.New(), uuid.New()
+	dob := time.Now().UTC().AddDate(-p.Age, 0, 0)
+	rows := []interface{}{
+		&entities.User{ID: userId, Name: p.Name, Email: userId.String() + "@example.com", Phone: userId.String(), IsBlocked: p.Blocked},
+		&entities.Profile{ID: profileId, UserId: userId, DateOfBirth: &dob},
+		&entities.Address{Id: uuid.New(), ProfileId: profileId, City: p.City, Country: p.Country},
+	}
+	if len(p.GenderIds) == 0 {
+		p.GenderIds = []int{p.GenderId}
+	}
+	for _, gender := range p.GenderIds {
+		rows = append(rows, &entities.UserGenders{ProfileId: profileId, GenderId: gender})
+	}
+	for _, row := range rows {
+		if err := tx.Omit(clause.Associations).Create(row).Error; err != nil {
+			t.Fatalf("seeding %T: %v", row, err)
+		}
+	}
+	return userId.String(), profileId.String()
+}
+
+// seedPreference stores the preference of profileId. A preference without
+// GenderIds accepts GenderId only.
+func seedPreference(t *testing.T, tx *gorm.DB, profileId string, pref entities.Preference) {
+	t.Helper()
+	if len(pref.GenderIds) == 0 {
+		pref.GenderIds = []int{pref.GenderId}
+	}
+	pref.GenderId = pref.GenderIds[0]
+	pref.ProfileId = uuid.MustParse(profileId)
+	if err := adapters.NewUserAdapter(tx).UserAddPreference(pref); err != nil {
+		t.Fatalf("seeding preference: %v", err)
+	}
+}
+
+// seedGender creates a gender with a unique name and returns its id.
+func seedGender(t *testing.T, tx *gorm.DB) int {
+	t.Helper()
+	gender := entities.Gender{Name: "gender-" + uuid.NewString()}
+	if err := tx.Create(&gender).Error; err != nil {
+		t.Fatalf("seeding gender: %v", err)
+	}
+	return gender.Id
+}
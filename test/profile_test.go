@@ -0,0 +1,144 @@
+package userServiceTest
+
+import (
+	"context"
+	"testing"
+
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestMaskPaths(t *testing.T) {
+	assert.Equal(t, []string{"bio"}, helper.MaskPaths(&fieldmaskpb.FieldMask{Paths: []string{"bio"}}, &pb.ProfileDetails{}))
+	assert.Equal(t,
+		[]string{"name", "bio", "occupation", "education", "heightCm", "drinking", "smoking", "exercise", "prompts"},
+		helper.MaskPaths(nil, &pb.ProfileDetails{}),
+		"an empty mask names every field")
+}
+
+func TestUpdateProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	profileTestUUID := uuid.New()
+	height := 170
+	stored := helperstruct.ProfileDetails{
+		Name:     "valid",
+		Bio:      "hiking and coffee",
+		HeightCm: &height,
+		Drinking: int(pb.Frequency_SOMETIMES),
+		Prompts:  []helperstruct.Prompt{{Question: "Perfect Sunday?", Answer: "A long walk"}},
+	}
+
+	tests := []struct {
+		name       string
+		paths      []string
+		wantUpdate helperstruct.ProfileUpdate
+	}{
+		{
+			name:  "only masked fields change",
+			paths: []string{"bio", "drinking", "heightCm"},
+			wantUpdate: helperstruct.ProfileUpdate{Columns: map[string]interface{}{
+				"bio":       "hiking and coffee",
+				"drinking":  int(pb.Frequency_SOMETIMES),
+				"height_cm": 170,
+			}},
+		},
+		{
+			name:  "prompts are replaced",
+			paths: []string{"prompts"},
+			wantUpdate: helperstruct.ProfileUpdate{
+				Columns: map[string]interface{}{},
+				Prompts: &[]helperstruct.Prompt{{Question: "Perfect Sunday?", Answer: "A long walk"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAdapters.EXPECT().GetProfileIdByUserId(testUUID.String()).Return(profileTestUUID.String(), nil)
+			mockAdapters.EXPECT().UpdateProfileDetails(profileTestUUID.String(), test.wantUpdate).Return(nil)
+			mockAdapters.EXPECT().GetProfileDetails(profileTestUUID.String()).Return(stored, nil)
+			mockAdapters.EXPECT().GetAge(profileTestUUID.String()).Return(27, nil)
+
+			res, err := userService.UpdateProfile(context.Background(), &pb.UpdateProfileRequest{
+				UserId: testUUID.String(),
+				Profile: &pb.ProfileDetails{
+					Name:     "ignored",
+					Bio:      "hiking and coffee",
+					HeightCm: 170,
+					Drinking: pb.Frequency_SOMETIMES,
+					Prompts:  []*pb.ProfilePrompt{{Question: "Perfect Sunday?", Answer: "A long walk"}},
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: test.paths},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, int32(27), res.Age)
+			assert.Equal(t, "valid", res.Profile.Name)
+			assert.Equal(t, int32(170), res.Profile.HeightCm)
+			assert.Equal(t, pb.Frequency_SOMETIMES, res.Profile.Drinking)
+			assert.Len(t, res.Profile.Prompts, 1)
+		})
+	}
+}
+
+func TestGetProfileNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	mockAdapters.EXPECT().GetProfileIdByUserId(testUUID.String()).Return("", nil)
+	_, err := userService.GetProfile(context.Background(), &pb.GetUserById{Id: testUUID.String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestProfileDetailsPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	_, profile := seedUser(t, tx, seedProfile{Name: "before", Age: 27, GenderId: seedGender(t, tx)})
+	name := "after"
+	prompts := []helperstruct.Prompt{{Question: "first", Answer: "1"}, {Question: "second", Answer: "2"}}
+	assert.NoError(t, repo.UpdateProfileDetails(profile, helperstruct.ProfileUpdate{
+		Columns: map[string]interface{}{"bio": "bio", "height_cm": 180, "smoking": int(pb.Frequency_NEVER)},
+		Name:    &name,
+		Prompts: &prompts,
+	}))
+	details, err := repo.GetProfileDetails(profile)
+	assert.NoError(t, err)
+	height := 180
+	assert.Equal(t, helperstruct.ProfileDetails{
+		Name:     "after",
+		Bio:      "bio",
+		HeightCm: &height,
+		Smoking:  int(pb.Frequency_NEVER),
+		Prompts:  prompts,
+	}, details)
+
+	// Columns left out of an update keep their values.
+	assert.NoError(t, repo.UpdateProfileDetails(profile, helperstruct.ProfileUpdate{
+		Columns: map[string]interface{}{"height_cm": nil},
+		Prompts: &[]helperstruct.Prompt{},
+	}))
+	details, err = repo.GetProfileDetails(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, "bio", details.Bio)
+	assert.Nil(t, details.HeightCm)
+	assert.Empty(t, details.Prompts)
+}
@@ -0,0 +1,151 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/go-redis/redis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMigrateSeenSets(t *testing.T) {
+	redisClient := testRedis(t)
+	profile := uuid.NewString()
+	legacyKey, seenKey := "displayed_user_ids:"+profile, "seen_users:"+profile
+	t.Cleanup(func() {
+		redisClient.Del(legacyKey, seenKey)
+	})
+	shown := []string{uuid.NewString(), uuid.NewString()}
+	assert.NoError(t, redisClient.SAdd(legacyKey, shown[0], shown[1]).Err())
+
+	userService := service.NewUserService(nil, nil, redisClient)
+	migrated, err := userService.MigrateSeenSets(context.Background())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, migrated, 1)
+
+	seen, err := redisClient.ZRange(seenKey, 0, -1).Result()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, shown, seen)
+	exists, err := redisClient.Exists(legacyKey).Result()
+	assert.NoError(t, err)
+	assert.Zero(t, exists, "migrated sets are removed")
+}
+
+// recycleUsers seeds a viewer with three visible candidates and returns a
+// service backed by tx and the test redis, cleaning up the viewer's keys.
+func recycleUsers(t *testing.T, tx *gorm.DB) (userService *service.UserService, redisClient *redis.Client, viewer, viewerProfile string, candidates []string) {
+	t.Helper()
+	repo := adapters.NewUserAdapter(tx)
+	viewer, viewerProfile, byName := discoveryUsers(t, tx)
+	for _, id := range byName {
+		if err := repo.SetDiscoveryMode(id, entities.DiscoveryVisible); err != nil {
+			t.Fatalf("setting discovery mode: %v", err)
+		}
+		candidates = append(candidates, id)
+	}
+	redisClient = testRedis(t)
+	t.Cleanup(func() {
+		redisClient.Del(fmt.Sprintf("seen_users:%s", viewerProfile), fmt.Sprintf("swipe_history:%s", viewerProfile))
+	})
+	return service.NewUserService(repo, nil, redisClient), redisClient, viewer, viewerProfile, candidates
+}
+
+func TestSeenUsersRecyclePostgres(t *testing.T) {
+	tx := testDB(t)
+	userService, redisClient, viewer, viewerProfile, candidates := recycleUsers(t, tx)
+	userService.SetSeenRecycling(time.Hour, 24*time.Hour)
+	seenKey := fmt.Sprintf("seen_users:%s", viewerProfile)
+	next := func() string {
+		res, err := userService.HomePage(context.Background(), &pb.GetUserById{Id: viewer})
+		if err != nil {
+			return ""
+		}
+		return res.Id
+	}
+
+	var shown []string
+	for range candidates {
+		shown = append(shown, next())
+	}
+	assert.ElementsMatch(t, candidates, shown)
+	assert.Empty(t, next(), "the pool is exhausted")
+	ttl, err := redisClient.TTL(seenKey).Result()
+	assert.NoError(t, err)
+	assert.True(t, ttl > 0 && ttl <= time.Hour, "seen users expire with the recycle period")
+	for _, id := range shown {
+		key := "seen_by:" + id
+		t.Cleanup(func() { redisClient.Del(key) })
+		indexed, err := redisClient.SIsMember(key, viewerProfile).Result()
+		assert.NoError(t, err)
+		assert.True(t, indexed, "shown users point back at the viewer")
+	}
+
+	passed, liked := shown[0], shown[1]
+	assert.NoError(t, redisClient.ZAdd(seenKey, redis.Z{Score: float64(time.Now().Add(-2 * time.Hour).Unix()), Member: passed}).Err())
+	assert.Equal(t, passed, next(), "passed users are recycled after the period")
+	assert.Empty(t, next())
+
+	_, err = userService.RecordLike(context.Background(), &pb.UserLikeRequest{UserId: viewer, LikedId: liked})
+	assert.NoError(t, err)
+	score, err := redisClient.ZScore(seenKey, liked).Result()
+	assert.Equal(t, redis.Nil, err, "liked users leave the seen users, got score %v", score)
+	assert.Empty(t, next(), "liked users stay out while the like is recent")
+
+	if err := tx.Exec(`UPDATE user_likes SET created_at=NOW() - interval '2 days' WHERE user_id=? AND liked_id=?`, viewer, liked).Error; err != nil {
+		t.Fatalf("backdating like: %v", err)
+	}
+	assert.Equal(t, liked, next(), "liked users are recycled after their own period")
+
+	userService.SetSeenRecycling(time.Hour, 0)
+	assert.NoError(t, redisClient.ZRem(seenKey, liked).Err())
+	assert.Empty(t, next(), "without a liked period liked users never come back")
+
+	_, err = userService.RemoveLike(context.Background(), &pb.UserLikeRequest{UserId: viewer, LikedId: liked})
+	assert.NoError(t, err)
+	assert.Empty(t, next(), "an unliked user counts as just passed")
+}
+
+func TestResetSeenOnPreferenceChangePostgres(t *testing.T) {
+	tx := testDB(t)
+	userService, _, viewer, viewerProfile, candidates := recycleUsers(t, tx)
+	next := func() string {
+		res, err := userService.HomePage(context.Background(), &pb.GetUserById{Id: viewer})
+		if err != nil {
+			return ""
+		}
+		return res.Id
+	}
+	for range candidates {
+		next()
+	}
+	_, err := userService.RecordLike(context.Background(), &pb.UserLikeRequest{UserId: viewer, LikedId: candidates[0]})
+	assert.NoError(t, err)
+
+	pref, err := adapters.NewUserAdapter(tx).FetchPreference(viewerProfile)
+	if !assert.NoError(t, err) || !assert.NotEmpty(t, pref.Genders) {
+		return
+	}
+	edit := &pb.PreferenceResponse{UserId: viewer, Minage: int32(pref.MinAge), Maxage: int32(pref.MaxAge), Gender: int32(pref.Genders[0]), Desirecity: pref.DesireCity}
+	_, err = userService.UserEditPreference(context.Background(), edit)
+	assert.NoError(t, err)
+	assert.Empty(t, next(), "seen users are kept unless resetting is enabled")
+
+	userService.SetResetSeenOnPreferenceChange(true)
+	_, err = userService.UserEditPreference(context.Background(), edit)
+	assert.NoError(t, err)
+	var shown []string
+	for i := 0; i < len(candidates); i++ {
+		if id := next(); id != "" {
+			shown = append(shown, id)
+		}
+	}
+	assert.ElementsMatch(t, candidates[1:], shown, "passed users come back and liked users stay out")
+}
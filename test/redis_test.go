@@ -0,0 +1,27 @@
+package userServiceTest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-redis/redis"
+)
+
+// testRedis connects to the Redis server at TEST_REDIS_ADDR. Keys outlive
+// the test, so tests should only use keys of the users they seed and delete
+// them when they end. Tests are skipped when the variable is not set.
+func testRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TEST_REDIS_ADDR not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping().Err(); err != nil {
+		t.Fatalf("connecting to test redis: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return client
+}
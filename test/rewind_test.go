@@ -0,0 +1,118 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRewindLastSwipeEntitlement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.NewString()
+	mockAdapters.EXPECT().GetEntitlements(testUUID).Return(helperstruct.Entitlements{PlanId: entities.PlanFree, DailyLikes: 3}, nil)
+	_, err := userService.RewindLastSwipe(context.Background(), &pb.GetUserById{Id: testUUID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	mockAdapters.EXPECT().GetEntitlements(testUUID).Return(helperstruct.Entitlements{}, nil)
+	_, err = userService.RewindLastSwipe(context.Background(), &pb.GetUserById{Id: testUUID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRewindLastSwipePostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	viewer, viewerProfile, candidates := discoveryUsers(t, tx)
+	for _, id := range candidates {
+		assert.NoError(t, repo.SetDiscoveryMode(id, entities.DiscoveryVisible))
+	}
+	redisClient := testRedis(t)
+	countKey := fmt.Sprintf("rewinds:%s:%s", viewer, time.Now().UTC().Format(time.DateOnly))
+	t.Cleanup(func() {
+		redisClient.Del(fmt.Sprintf("seen_users:%s", viewerProfile), fmt.Sprintf("swipe_history:%s", viewerProfile), countKey)
+	})
+	userService := service.NewUserService(repo, nil, redisClient)
+	next := func() string {
+		res, err := userService.HomePage(context.Background(), &pb.GetUserById{Id: viewer})
+		if err != nil {
+			return ""
+		}
+		return res.Id
+	}
+	rewind := func() (*pb.RewindResponse, error) {
+		return userService.RewindLastSwipe(context.Background(), &pb.GetUserById{Id: viewer})
+	}
+
+	_, err := rewind()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "the free plan has no rewinds")
+	assert.NoError(t, repo.StartSubscription(entities.Subscription{
+		Id:        uuid.New(),
+		UserId:    uuid.MustParse(viewer),
+		PlanId:    entities.PlanPlus,
+		Status:    entities.SubscriptionActive,
+		StartedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+	entitlements, err := repo.GetEntitlements(viewer)
+	assert.NoError(t, err)
+	if entitlements.DailyRewinds < 2 {
+		t.Skipf("the plus plan has %d daily rewinds", entitlements.DailyRewinds)
+	}
+	_, err = rewind()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "nothing was shown yet")
+
+	var shown []string
+	for i := 0; i < len(candidates); i++ {
+		shown = append(shown, next())
+	}
+	assert.Empty(t, next(), "every candidate has been shown")
+	last := shown[len(shown)-1]
+
+	res, err := rewind()
+	if assert.NoError(t, err) {
+		assert.Equal(t, last, res.RewoundUserId)
+		assert.Equal(t, int32(entitlements.DailyRewinds-1), res.RewindsLeft)
+	}
+	assert.Equal(t, last, next(), "the rewound user is recommended again")
+
+	assert.NoError(t, repo.RecordLike(viewer, last))
+	res, err = rewind()
+	if assert.NoError(t, err) {
+		assert.Equal(t, shown[len(shown)-2], res.RewoundUserId, "likes are skipped and the user shown before is rewound")
+		assert.Equal(t, int32(entitlements.DailyRewinds-2), res.RewindsLeft)
+	}
+	history, err := redisClient.LRange(fmt.Sprintf("swipe_history:%s", viewerProfile), 0, -1).Result()
+	assert.NoError(t, err)
+	assert.NotContains(t, history, last, "skipped likes leave the history")
+
+	for _, id := range history {
+		assert.NoError(t, repo.RecordLike(viewer, id))
+	}
+	_, err = rewind()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "a history of likes has nothing to rewind")
+	used, err := redisClient.Get(countKey).Int()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, used, "a refused rewind is not counted")
+
+	assert.NoError(t, redisClient.Set(countKey, entitlements.DailyRewinds, time.Hour).Err())
+	_, err = rewind()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
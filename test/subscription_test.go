@@ -0,0 +1,364 @@
+package userServiceTest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestUpdateSubscriptionPlans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	_, err := userService.UpdateSubscription(context.Background(), &pb.UpdateSubscriptionRequest{UserId: testUUID.String(), Subscription: true, PlanId: entities.PlanFree})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mockAdapters.EXPECT().ApplySubscriptionEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error) {
+		assert.NotEmpty(t, event.Id, "direct updates are recorded as events")
+		if assert.NotNil(t, sub) {
+			assert.Equal(t, testUUID, sub.UserId)
+			assert.Equal(t, entities.PlanGold, sub.PlanId)
+			assert.Equal(t, "pay_123", sub.PaymentRef)
+			assert.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), sub.ExpiresAt.UTC())
+		}
+		return entities.SubscriptionEventApplied, nil
+	})
+	_, err = userService.UpdateSubscription(context.Background(), &pb.UpdateSubscriptionRequest{
+		UserId:       testUUID.String(),
+		Subscription: true,
+		PlanId:       entities.PlanGold,
+		ExpiresAt:    "2030-01-01T00:00:00Z",
+		PaymentRef:   "pay_123",
+	})
+	assert.NoError(t, err)
+
+	mockAdapters.EXPECT().ApplySubscriptionEvent(gomock.Any(), gomock.Any()).Return("", gorm.ErrForeignKeyViolated)
+	_, err = userService.UpdateSubscription(context.Background(), &pb.UpdateSubscriptionRequest{UserId: testUUID.String(), Subscription: true, PlanId: "platinum"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	mockAdapters.EXPECT().ApplySubscriptionEvent(gomock.Any(), nil).DoAndReturn(func(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error) {
+		assert.Equal(t, entities.SubscriptionEventCancelled, event.Type)
+		return entities.SubscriptionEventApplied, nil
+	})
+	_, err = userService.UpdateSubscription(context.Background(), &pb.UpdateSubscriptionRequest{UserId: testUUID.String()})
+	assert.NoError(t, err)
+
+	mockAdapters.EXPECT().ApplySubscriptionEvent(gomock.Any(), nil).Return(entities.SubscriptionEventStale, nil)
+	_, err = userService.UpdateSubscription(context.Background(), &pb.UpdateSubscriptionRequest{UserId: testUUID.String()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "a direct update does not override a newer event")
+}
+
+func TestGetEntitlements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.NewString()
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockAdapters.EXPECT().GetEntitlements(testUUID).Return(helperstruct.Entitlements{
+		PlanId:         entities.PlanGold,
+		PlanName:       "Gold",
+		DailyLikes:     100,
+		DailyRewinds:   10,
+		SeeWhoLikedYou: true,
+		ExpiresAt:      &expiresAt,
+	}, nil)
+	res, err := userService.GetEntitlements(context.Background(), &pb.GetUserById{Id: testUUID})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PlanGold, res.Plan.Id)
+	assert.Equal(t, int32(100), res.Plan.DailyLikes)
+	assert.True(t, res.Plan.SeeWhoLikedYou)
+	assert.Equal(t, "2030-01-01T00:00:00Z", res.ExpiresAt)
+
+	mockAdapters.EXPECT().GetEntitlements(testUUID).Return(helperstruct.Entitlements{}, nil)
+	_, err = userService.GetEntitlements(context.Background(), &pb.GetUserById{Id: testUUID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSubscriptionLifecyclePostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	plans := map[string]entities.Plan{}
+	all, err := repo.GetPlans()
+	assert.NoError(t, err)
+	for _, plan := range all {
+		plans[plan.Id] = plan
+	}
+	if !assert.Contains(t, plans, entities.PlanFree) || !assert.Contains(t, plans, entities.PlanGold) {
+		return
+	}
+	userId, _ := seedUser(t, tx, seedProfile{Name: "subscriber", Age: 27, GenderId: seedGender(t, tx)})
+	account := func() entities.User {
+		t.Helper()
+		u, err := repo.GetUserById(userId)
+		assert.NoError(t, err)
+		return u
+	}
+
+	entitlements, err := repo.GetEntitlements(userId)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PlanFree, entitlements.PlanId)
+	assert.Nil(t, entitlements.ExpiresAt)
+	entitlements, err = repo.GetEntitlements(uuid.NewString())
+	assert.NoError(t, err)
+	assert.Empty(t, entitlements.PlanId, "unknown users have no plan")
+
+	start := func(planId string) {
+		t.Helper()
+		assert.NoError(t, repo.StartSubscription(entities.Subscription{
+			Id:        uuid.New(),
+			UserId:    uuid.MustParse(userId),
+			PlanId:    planId,
+			Status:    entities.SubscriptionActive,
+			StartedAt: time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}))
+	}
+	start(entities.PlanPlus)
+	start(entities.PlanGold)
+	entitlements, err = repo.GetEntitlements(userId)
+	assert.NoError(t, err)
+	assert.Equal(t, plans[entities.PlanGold].DailyLikes, entitlements.DailyLikes)
+	assert.NotNil(t, entitlements.ExpiresAt)
+	assert.True(t, account().IsSubscribed)
+	assert.Equal(t, plans[entities.PlanGold].DailyLikes, account().LikeCount, "upgrading grants the plan's likes at once")
+	var active int
+	if err := tx.Raw(`SELECT COUNT(*) FROM subscriptions WHERE user_id=? AND status=?`, userId, entities.SubscriptionActive).Scan(&active).Error; err != nil {
+		t.Fatalf("counting active subscriptions: %v", err)
+	}
+	assert.Equal(t, 1, active, "a new subscription replaces the active one")
+
+	if err := tx.Exec(`UPDATE subscriptions SET expires_at=NOW() - interval '1 minute' WHERE user_id=? AND status=?`, userId, entities.SubscriptionActive).Error; err != nil {
+		t.Fatalf("backdating subscription: %v", err)
+	}
+	entitlements, err = repo.GetEntitlements(userId)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PlanFree, entitlements.PlanId, "lapsed subscriptions grant nothing before the expiry job runs")
+
+	expired, err := repo.ExpireSubscriptions()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, expired, int64(1))
+	assert.False(t, account().IsSubscribed)
+	assert.LessOrEqual(t, account().LikeCount, plans[entities.PlanFree].DailyLikes)
+
+	start(entities.PlanGold)
+	assert.NoError(t, tx.Exec(`UPDATE users SET like_count=0 WHERE id=?`, userId).Error)
+	_, err = repo.ResetLikeQuotas()
+	assert.NoError(t, err)
+	assert.Equal(t, plans[entities.PlanGold].DailyLikes, account().LikeCount)
+
+	assert.NoError(t, repo.CancelSubscription(userId))
+	_, err = repo.ResetLikeQuotas()
+	assert.NoError(t, err)
+	assert.Equal(t, plans[entities.PlanFree].DailyLikes, account().LikeCount)
+	assert.False(t, account().IsSubscribed)
+
+	assert.NoError(t, tx.Exec(`UPDATE users SET like_count=1 WHERE id=?`, userId).Error)
+	assert.NoError(t, repo.DecrementLikeCount(userId))
+	assert.ErrorIs(t, repo.DecrementLikeCount(userId), adapters.ErrNoLikesLeft)
+	assert.Zero(t, account().LikeCount, "likes do not go below zero")
+}
+
+func TestApplySubscriptionEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	testUUID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	tests := []struct {
+		name        string
+		eventType   pb.SubscriptionEventType
+		expiresAt   string
+		outcome     string
+		err         error
+		wantSub     bool
+		wantOutcome pb.SubscriptionEventOutcome
+		wantCode    codes.Code
+	}{
+		{name: "Success - activated", eventType: pb.SubscriptionEventType_ACTIVATED, expiresAt: expiresAt.Format(time.RFC3339), outcome: entities.SubscriptionEventApplied, wantSub: true, wantOutcome: pb.SubscriptionEventOutcome_APPLIED},
+		{name: "Success - cancelled keeps the paid period", eventType: pb.SubscriptionEventType_CANCELLED, expiresAt: expiresAt.Format(time.RFC3339), outcome: entities.SubscriptionEventApplied, wantSub: true, wantOutcome: pb.SubscriptionEventOutcome_APPLIED},
+		{name: "Success - refunded ends at once", eventType: pb.SubscriptionEventType_REFUNDED, expiresAt: expiresAt.Format(time.RFC3339), outcome: entities.SubscriptionEventApplied, wantOutcome: pb.SubscriptionEventOutcome_APPLIED},
+		{name: "Success - renewal that already ran out", eventType: pb.SubscriptionEventType_RENEWED, expiresAt: "2020-01-01T00:00:00Z", outcome: entities.SubscriptionEventApplied, wantOutcome: pb.SubscriptionEventOutcome_APPLIED},
+		{name: "Success - replayed", eventType: pb.SubscriptionEventType_ACTIVATED, expiresAt: expiresAt.Format(time.RFC3339), outcome: entities.SubscriptionEventDuplicate, wantSub: true, wantOutcome: pb.SubscriptionEventOutcome_DUPLICATE},
+		{name: "Fail - unknown user", eventType: pb.SubscriptionEventType_ACTIVATED, expiresAt: expiresAt.Format(time.RFC3339), err: gorm.ErrRecordNotFound, wantSub: true, wantCode: codes.NotFound},
+		{name: "Fail - unknown type", eventType: pb.SubscriptionEventType(9), wantCode: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantCode != codes.InvalidArgument {
+				mockAdapters.EXPECT().ApplySubscriptionEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error) {
+						assert.Equal(t, "evt_1", event.Id)
+						assert.Equal(t, time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC), event.OccurredAt.UTC())
+						if !test.wantSub {
+							assert.Nil(t, sub)
+						} else if assert.NotNil(t, sub) {
+							assert.Equal(t, entities.PlanGold, sub.PlanId)
+							assert.Equal(t, expiresAt, sub.ExpiresAt.UTC())
+						}
+						return test.outcome, test.err
+					})
+			}
+			res, err := userService.ApplySubscriptionEvent(context.Background(), &pb.SubscriptionEventRequest{
+				EventId:    "evt_1",
+				UserId:     testUUID.String(),
+				Type:       test.eventType,
+				OccurredAt: "2026-05-01T10:00:00Z",
+				PlanId:     entities.PlanGold,
+				ExpiresAt:  test.expiresAt,
+			})
+			assert.Equal(t, test.wantCode, status.Code(err))
+			if err == nil {
+				assert.Equal(t, test.wantOutcome, res.Outcome)
+			}
+		})
+	}
+}
+
+func TestApplySubscriptionEventsShuffledPostgres(t *testing.T) {
+	tx := testDB(t)
+	userService := service.NewUserService(adapters.NewUserAdapter(tx), nil, nil)
+	gender := seedGender(t, tx)
+
+	base := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	later := func(d time.Duration) string {
+		return time.Now().Add(d).UTC().Truncate(time.Second).Format(time.RFC3339)
+	}
+	event := func(id string, eventType pb.SubscriptionEventType, occurredAfter time.Duration, planId, expiresAt string) *pb.SubscriptionEventRequest {
+		return &pb.SubscriptionEventRequest{
+			EventId:    id,
+			Type:       eventType,
+			OccurredAt: base.Add(occurredAfter).Format(time.RFC3339),
+			PlanId:     planId,
+			ExpiresAt:  expiresAt,
+		}
+	}
+	history := []*pb.SubscriptionEventRequest{
+		event("activated", pb.SubscriptionEventType_ACTIVATED, 0, entities.PlanPlus, later(24*time.Hour)),
+		event("upgraded", pb.SubscriptionEventType_RENEWED, time.Hour, entities.PlanGold, later(48*time.Hour)),
+		event("cancelled", pb.SubscriptionEventType_CANCELLED, 2*time.Hour, entities.PlanGold, later(48*time.Hour)),
+		event("refunded", pb.SubscriptionEventType_REFUNDED, 3*time.Hour, "", ""),
+		event("reactivated", pb.SubscriptionEventType_ACTIVATED, 4*time.Hour, entities.PlanPlus, later(72*time.Hour)),
+	}
+
+	for _, n := range []int{3, 4, 5} {
+		events := history[:n]
+		newest := events[n-1]
+		for seed := int64(0); seed < 5; seed++ {
+			t.Run(fmt.Sprintf("%s/seed %d", newest.EventId, seed), func(t *testing.T) {
+				userId, _ := seedUser(t, tx, seedProfile{Name: "subscriber", Age: 27, GenderId: gender})
+				r := rand.New(rand.NewSource(seed))
+				// Deliver every event once in a random order, then replay
+				// a random few of them.
+				deliveries := append([]*pb.SubscriptionEventRequest{}, events...)
+				r.Shuffle(len(deliveries), func(i, j int) { deliveries[i], deliveries[j] = deliveries[j], deliveries[i] })
+				for i := 0; i < 3; i++ {
+					deliveries = append(deliveries, deliveries[r.Intn(len(events))])
+				}
+
+				seen := map[string]bool{}
+				for _, delivery := range deliveries {
+					req := &pb.SubscriptionEventRequest{
+						EventId:    userId + "/" + delivery.EventId,
+						UserId:     userId,
+						Type:       delivery.Type,
+						OccurredAt: delivery.OccurredAt,
+						PlanId:     delivery.PlanId,
+						ExpiresAt:  delivery.ExpiresAt,
+					}
+					res, err := userService.ApplySubscriptionEvent(context.Background(), req)
+					if !assert.NoError(t, err) {
+						return
+					}
+					if seen[req.EventId] {
+						assert.Equal(t, pb.SubscriptionEventOutcome_DUPLICATE, res.Outcome, req.EventId)
+					} else {
+						assert.NotEqual(t, pb.SubscriptionEventOutcome_DUPLICATE, res.Outcome, req.EventId)
+					}
+					seen[req.EventId] = true
+				}
+
+				res, err := userService.GetEntitlements(context.Background(), &pb.GetUserById{Id: userId})
+				if !assert.NoError(t, err) {
+					return
+				}
+				if newest.Type == pb.SubscriptionEventType_REFUNDED {
+					assert.Equal(t, entities.PlanFree, res.Plan.Id, "the newest event decides the plan")
+					assert.Empty(t, res.ExpiresAt)
+				} else {
+					assert.Equal(t, newest.PlanId, res.Plan.Id, "the newest event decides the plan")
+					assert.Equal(t, newest.ExpiresAt, res.ExpiresAt)
+				}
+
+				var ledger []entities.SubscriptionEvent
+				if err := tx.Where("user_id = ?", userId).Find(&ledger).Error; err != nil {
+					t.Fatalf("reading ledger: %v", err)
+				}
+				assert.Len(t, ledger, len(events), "every event is recorded once")
+				for _, e := range ledger {
+					if e.Id == userId+"/"+newest.EventId {
+						assert.Equal(t, entities.SubscriptionEventApplied, e.Outcome)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestLateUpdateSubscriptionPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+	userService := service.NewUserService(repo, nil, nil)
+	userId, _ := seedUser(t, tx, seedProfile{Name: "subscriber", Age: 27, GenderId: seedGender(t, tx)})
+
+	occurredAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	_, err := userService.ApplySubscriptionEvent(context.Background(), &pb.SubscriptionEventRequest{
+		EventId:    userId + "/activated",
+		UserId:     userId,
+		Type:       pb.SubscriptionEventType_ACTIVATED,
+		OccurredAt: occurredAt.Format(time.RFC3339),
+		PlanId:     entities.PlanGold,
+		ExpiresAt:  time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	assert.NoError(t, err)
+
+	_, err = userService.UpdateSubscription(context.Background(), &pb.UpdateSubscriptionRequest{
+		UserId:     userId,
+		OccurredAt: occurredAt.Add(-time.Hour).Format(time.RFC3339),
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	entitlements, err := repo.GetEntitlements(userId)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PlanGold, entitlements.PlanId, "a late update leaves the newer event in effect")
+
+	_, err = userService.UpdateSubscription(context.Background(), &pb.UpdateSubscriptionRequest{UserId: userId})
+	assert.NoError(t, err)
+	entitlements, err = repo.GetEntitlements(userId)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PlanFree, entitlements.PlanId, "an update made now is the newest event")
+}
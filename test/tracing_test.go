@@ -0,0 +1,37 @@
+package userServiceTest
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingLogHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	var buf bytes.Buffer
+	logger := slog.New(tracing.LogHandler{Handler: slog.NewTextHandler(&buf, nil)})
+
+	logger.InfoContext(context.Background(), "no span")
+	assert.NotContains(t, buf.String(), "trace_id")
+	buf.Reset()
+
+	ctx, span := tracing.Start(context.Background(), "test")
+	logger.With("user_id", "1").InfoContext(ctx, "inside span")
+	tracing.End(span, nil)
+	assert.Contains(t, buf.String(), "trace_id="+span.SpanContext().TraceID().String())
+	assert.Contains(t, buf.String(), "user_id=1")
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "test", spans[0].Name())
+	}
+}
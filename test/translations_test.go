@@ -0,0 +1,190 @@
+package userServiceTest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akshaybt001/DatingApp_UserService/entities"
+	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
+	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/service"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: nil},
+		{header: "ml", want: []string{"ml"}},
+		{header: "pt-BR, en", want: []string{"pt-br", "pt", "en"}},
+		{header: "en;q=0.5, hi-IN;q=0.9, *", want: []string{"hi-in", "hi", "en"}},
+		{header: "en-GB, en-US;q=0.8", want: []string{"en-gb", "en-us", "en"}},
+		{header: "de;q=0, fr_CA", want: []string{"fr-ca", "fr"}},
+	}
+	for _, test := range tests {
+		t.Run(test.header, func(t *testing.T) {
+			assert.Equal(t, test.want, helper.ParseAcceptLanguage(test.header))
+		})
+	}
+}
+
+func TestLocales(t *testing.T) {
+	assert.Nil(t, helper.Locales(context.Background()))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(helper.AcceptLanguageHeader, "ta-IN,en;q=0.7"))
+	assert.Equal(t, []string{"ta-in", "ta", "en"}, helper.Locales(ctx))
+}
+
+func TestAdminUpsertTranslation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	mockAdapters.EXPECT().GetGenderById(3).Return(helperstruct.GenderHelper{GenderId: 3, GenderName: "woman"}, nil)
+	mockAdapters.EXPECT().UpsertTranslation(entities.Translation{
+		Entity:   entities.TranslationGender,
+		EntityId: 3,
+		Locale:   "pt-br",
+		Name:     "mulher",
+	}).Return(nil)
+	_, err := userService.AdminUpsertTranslation(context.Background(), &pb.TranslationRequest{
+		Entity:   entities.TranslationGender,
+		EntityId: 3,
+		Locale:   "pt_BR",
+		Name:     "mulher",
+	})
+	assert.NoError(t, err)
+
+	mockAdapters.EXPECT().GetInterestById(7).Return(helperstruct.InterestHelper{}, nil)
+	_, err = userService.AdminUpsertTranslation(context.Background(), &pb.TranslationRequest{
+		Entity:   entities.TranslationInterest,
+		EntityId: 7,
+		Locale:   "ml",
+		Name:     "സംഗീതം",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetAllGenderLocales(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	userService := service.NewUserService(mockAdapters, nil, nil)
+
+	mockAdapters.EXPECT().AdminGetAllGender([]string{"hi"}).Return([]entities.Gender{{Id: 1, Name: "महिला"}}, nil)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(helper.AcceptLanguageHeader, "hi"))
+	srv := &catalogGenderStream{ctx: ctx}
+	assert.NoError(t, userService.GetAllGender(&pb.NoArg{}, srv))
+	assert.Equal(t, []*pb.GenderResponse{{Id: 1, Gender: "महिला"}}, srv.sent)
+}
+
+type catalogGenderStream struct {
+	pb.UserService_GetAllGenderServer
+	ctx  context.Context
+	sent []*pb.GenderResponse
+}
+
+func (s *catalogGenderStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *catalogGenderStream) Send(res *pb.GenderResponse) error {
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+func TestTranslationsPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	gender := seedGender(t, tx)
+	for _, tr := range []entities.Translation{
+		{Entity: entities.TranslationGender, EntityId: gender, Locale: "pt", Name: "pt"},
+		{Entity: entities.TranslationGender, EntityId: gender, Locale: "pt-br", Name: "old"},
+		{Entity: entities.TranslationGender, EntityId: gender, Locale: "pt-br", Name: "pt-br"},
+		{Entity: entities.TranslationInterest, EntityId: gender, Locale: "en", Name: "not a gender"},
+	} {
+		assert.NoError(t, repo.UpsertTranslation(tr))
+	}
+
+	nameIn := func(locales ...string) string {
+		genders, err := repo.AdminGetAllGender(locales)
+		assert.NoError(t, err)
+		for _, g := range genders {
+			if g.Id == gender {
+				return g.Name
+			}
+		}
+		t.Fatalf("gender %d not listed", gender)
+		return ""
+	}
+	var name string
+	if err := tx.Raw(`SELECT name FROM genders WHERE id=?`, gender).Scan(&name).Error; err != nil {
+		t.Fatalf("reading gender: %v", err)
+	}
+	assert.Equal(t, "pt-br", nameIn("pt-br", "pt"), "upsert replaces the name")
+	assert.Equal(t, "pt", nameIn("pt"))
+	assert.Equal(t, "pt", nameIn("fr", "pt", "pt-br"), "the first locale with a translation wins")
+	assert.Equal(t, name, nameIn("en"), "translations of other entities are ignored")
+	assert.Equal(t, name, nameIn())
+
+	assert.NoError(t, repo.DeleteTranslation(entities.Translation{Entity: entities.TranslationGender, EntityId: gender, Locale: "pt-br"}))
+	assert.Equal(t, "pt", nameIn("pt-br", "pt"))
+
+	city := "city-" + uuid.NewString()
+	_, viewer := seedUser(t, tx, seedProfile{Name: "viewer", Age: 27, GenderId: gender, City: city})
+	seedUser(t, tx, seedProfile{Name: "candidate", Age: 27, GenderId: gender, City: city})
+	users, err := repo.FetchCandidates(helperstruct.CandidateQuery{ProfileId: viewer, MinAge: 27, MaxAge: 27, City: city, Locales: []string{"pt"}})
+	assert.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, "pt", users[0].Gender, "candidate cards show translated genders")
+	}
+}
+
+func TestTranslatedInterestOrderPostgres(t *testing.T) {
+	tx := testDB(t)
+	repo := adapters.NewUserAdapter(tx)
+
+	category := entities.InterestCategory{Name: "category-" + uuid.NewString()}
+	if err := tx.Create(&category).Error; err != nil {
+		t.Fatalf("seeding category: %v", err)
+	}
+	apple := entities.Interests{Interest: "apple-" + uuid.NewString(), CategoryId: &category.Id}
+	zebra := entities.Interests{Interest: "zebra-" + uuid.NewString(), CategoryId: &category.Id}
+	for _, i := range []*entities.Interests{&apple, &zebra} {
+		if err := tx.Create(i).Error; err != nil {
+			t.Fatalf("seeding interest: %v", err)
+		}
+	}
+	for _, tr := range []entities.Translation{
+		{Entity: entities.TranslationInterest, EntityId: apple.Id, Locale: "pt-br", Name: "Zimbro"},
+		{Entity: entities.TranslationInterest, EntityId: zebra.Id, Locale: "pt-br", Name: "abacaxi"},
+	} {
+		assert.NoError(t, repo.UpsertTranslation(tr))
+	}
+
+	order := func(locales ...string) []int {
+		interests, err := repo.AdminGetAllInterest(category.Id, locales)
+		assert.NoError(t, err)
+		var ids []int
+		for _, i := range interests {
+			ids = append(ids, i.InterestId)
+		}
+		return ids
+	}
+	assert.Equal(t, []int{apple.Id, zebra.Id}, order())
+	assert.Equal(t, []int{zebra.Id, apple.Id}, order("pt-br"), "interests are sorted by their translated names")
+}
@@ -2,12 +2,18 @@ package userServiceTest
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/akshaybt001/DatingApp_UserService/entities"
 	helperstruct "github.com/akshaybt001/DatingApp_UserService/entities/helperStruct"
+	"github.com/akshaybt001/DatingApp_UserService/internal/adapters"
 	mock_adapters "github.com/akshaybt001/DatingApp_UserService/internal/adapters/mockAdapters"
 	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
 	"github.com/akshaybt001/DatingApp_UserService/internal/service"
@@ -16,6 +22,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
 func TestUserLogin(t *testing.T) {
@@ -23,7 +32,7 @@ func TestUserLogin(t *testing.T) {
 	defer ctrl.Finish()
 	adapter := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapter, usecase)
+	userService := service.NewUserService(adapter, usecase, nil)
 	hashedPass, err := helper.HashPassword("valid")
 	if err != nil {
 		t.Fatalf("failed to hash password: %v", err)
@@ -97,7 +106,7 @@ func TestAdminLogin(t *testing.T) {
 	defer ctrl.Finish()
 	adapter := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapter, usecase)
+	userService := service.NewUserService(adapter, usecase, nil)
 	hashedPass, err := helper.HashPassword("valid")
 	if err != nil {
 		t.Fatalf("failed to hash password: %v", err)
@@ -171,7 +180,7 @@ func TestUserSignup(t *testing.T) {
 	defer ctrl.Finish()
 	adapter := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userSerive := service.NewUserService(adapter, usecase)
+	userSerive := service.NewUserService(adapter, usecase, nil)
 	tests := []struct {
 		name               string
 		request            *pb.UserSignupRequest
@@ -294,48 +303,39 @@ func TestAddInterstAdmin(t *testing.T) {
 	defer ctrl.Finish()
 	adapter := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapter, usecase)
+	userService := service.NewUserService(adapter, usecase, nil)
 	tests := []struct {
-		name                  string
-		request               *pb.AddInterestRequest
-		mockGetInterestByName func(string) (entities.Interests, error)
-		wantError             bool
+		name                 string
+		request              *pb.AddInterestRequest
+		mockAdminAddInterest func(entities.Interests) error
+		wantCode             codes.Code
 	}{
 		{
 			name: "Success",
 			request: &pb.AddInterestRequest{
 				Interest: "valid",
 			},
-			mockGetInterestByName: func(s string) (entities.Interests, error) {
-				return entities.Interests{}, nil
+			mockAdminAddInterest: func(i entities.Interests) error {
+				return nil
 			},
-			wantError: false,
+			wantCode: codes.OK,
 		},
 		{
-			name: "Fail",
+			name: "Fail - interest already exist",
 			request: &pb.AddInterestRequest{
-				Interest: "valid",
+				Interest: "Valid",
 			},
-			mockGetInterestByName: func(s string) (entities.Interests, error) {
-				return entities.Interests{
-					Interest: "valid",
-				}, nil
+			mockAdminAddInterest: func(i entities.Interests) error {
+				return gorm.ErrDuplicatedKey
 			},
-			wantError: true,
+			wantCode: codes.AlreadyExists,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			adapter.EXPECT().GetInterestByName(gomock.Any()).DoAndReturn(test.mockGetInterestByName).AnyTimes().Times(1)
-			if !test.wantError {
-				adapter.EXPECT().AdminAddInterest(gomock.Any()).Return(nil).Times(1)
-			}
+			adapter.EXPECT().AdminAddInterest(gomock.Any()).DoAndReturn(test.mockAdminAddInterest).Times(1)
 			_, err := userService.AdminAddInterest(context.Background(), test.request)
-			if test.wantError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
+			assert.Equal(t, test.wantCode, status.Code(err))
 		})
 	}
 }
@@ -345,12 +345,12 @@ func TestAdminUpdateInterest(t *testing.T) {
 	defer ctrl.Finish()
 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapters, usecase)
+	userService := service.NewUserService(adapters, usecase, nil)
 	tests := []struct {
-		name                  string
-		request               *pb.InterestResponse
-		mockGetInterestByName func(string) (entities.Interests, error)
-		wantError             bool
+		name                    string
+		request                 *pb.InterestResponse
+		mockAdminUpdateInterest func(entities.Interests) error
+		wantCode                codes.Code
 	}{
 		{
 			name: "Success",
@@ -358,37 +358,28 @@ func TestAdminUpdateInterest(t *testing.T) {
 				Id:       1,
 				Interest: "valid",
 			},
-			mockGetInterestByName: func(s string) (entities.Interests, error) {
-				return entities.Interests{}, nil
+			mockAdminUpdateInterest: func(i entities.Interests) error {
+				return nil
 			},
-			wantError: false,
+			wantCode: codes.OK,
 		},
 		{
-			name: "Fail",
+			name: "Fail - interest already exist",
 			request: &pb.InterestResponse{
 				Id:       1,
-				Interest: "valid",
+				Interest: "VALID",
 			},
-			mockGetInterestByName: func(s string) (entities.Interests, error) {
-				return entities.Interests{
-					Interest: "valid",
-				}, nil
+			mockAdminUpdateInterest: func(i entities.Interests) error {
+				return gorm.ErrDuplicatedKey
 			},
-			wantError: true,
+			wantCode: codes.AlreadyExists,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			adapters.EXPECT().GetInterestByName(gomock.Any()).DoAndReturn(test.mockGetInterestByName).AnyTimes().Times(1)
-			if !test.wantError {
-				adapters.EXPECT().AdminUpdateInterest(gomock.Any()).Return(nil).Times(1)
-			}
+			adapters.EXPECT().AdminUpdateInterest(gomock.Any()).DoAndReturn(test.mockAdminUpdateInterest).Times(1)
 			_, err := userService.AdminUpdateInterest(context.Background(), test.request)
-			if test.wantError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
+			assert.Equal(t, test.wantCode, status.Code(err))
 		})
 	}
 }
@@ -399,7 +390,7 @@ func TestAddInterestUser(t *testing.T) {
 
 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapters, usecase)
+	userService := service.NewUserService(adapters, usecase, nil)
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
 	tests := []struct {
@@ -408,7 +399,7 @@ func TestAddInterestUser(t *testing.T) {
 		mockGetInterestById      func(int) (helperstruct.InterestHelper, error)
 		mockGetProfileIdByUserId func(string) (string, error)
 		mockGetUserInterestById  func(string, int) (entities.UserInterests, error)
-		mockUserAddInterest      func(entities.UserInterests) error
+		mockUserAddInterest      func(entities.UserInterests, int) error
 		wantError                bool
 	}{
 		{
@@ -428,7 +419,7 @@ func TestAddInterestUser(t *testing.T) {
 			mockGetUserInterestById: func(s string, i int) (entities.UserInterests, error) {
 				return entities.UserInterests{}, nil
 			},
-			mockUserAddInterest: func(ui entities.UserInterests) error {
+			mockUserAddInterest: func(ui entities.UserInterests, max int) error {
 				return nil
 			},
 			wantError: false,
@@ -480,8 +471,8 @@ func TestAddInterestUser(t *testing.T) {
 			adapters.EXPECT().GetInterestById(int(test.request.InterestId)).DoAndReturn(test.mockGetInterestById).AnyTimes()
 			adapters.EXPECT().GetProfileIdByUserId(test.request.UserId).DoAndReturn(test.mockGetProfileIdByUserId).AnyTimes()
 			adapters.EXPECT().GetUserInterestById(gomock.Any(), int(test.request.InterestId)).DoAndReturn(test.mockGetUserInterestById).AnyTimes()
-			if !test.wantError {
-				adapters.EXPECT().UserAddInterest(gomock.Any()).DoAndReturn(test.mockUserAddInterest).AnyTimes()
+			if test.mockUserAddInterest != nil {
+				adapters.EXPECT().UserAddInterestWithin(gomock.Any(), service.DefaultMaxInterests).DoAndReturn(test.mockUserAddInterest).Times(1)
 			}
 
 			_, err := userService.AddInterestUser(context.Background(), test.request)
@@ -500,7 +491,7 @@ func TestAddGenderUser(t *testing.T) {
 
 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapters, usecase)
+	userService := service.NewUserService(adapters, usecase, nil)
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
 	tests := []struct {
@@ -579,7 +570,7 @@ func TestAddGenderUser(t *testing.T) {
 
 // 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 // 	usecase := mock_usecases.NewMockUsecases(ctrl)
-// 	userService := service.NewUserService(adapters, usecase)
+// 	userService := service.NewUserService(adapters, usecase, nil)
 // 	testUUID := uuid.New()
 // 	profileTestUUID := uuid.New()
 // 	tests := []struct {
@@ -613,7 +604,7 @@ func TestDeleteInterestUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
@@ -677,7 +668,7 @@ func TestUserAddAddress(t *testing.T) {
 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
 	defer ctrl.Finish()
-	userService := service.NewUserService(adapters, usecase)
+	userService := service.NewUserService(adapters, usecase, nil)
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
 	tests := []struct {
@@ -754,7 +745,7 @@ func TestUserAddPreference(t *testing.T) {
 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
 	defer ctrl.Finish()
-	userService := service.NewUserService(adapters, usecase)
+	userService := service.NewUserService(adapters, usecase, nil)
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
 	tests := []struct {
@@ -785,6 +776,28 @@ func TestUserAddPreference(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "Success - several genders",
+			request: &pb.PreferenceRequest{
+				Minage:     18,
+				Maxage:     26,
+				Genders:    []int32{2, 1, 2},
+				Desirecity: "validCity",
+				UserId:     testUUID.String(),
+			},
+			mockGetProfileIdByUserId: func(s string) (string, error) {
+				return profileTestUUID.String(), nil
+			},
+			mockGetPreferenceByProfileId: func(s string) (entities.Preference, error) {
+				return entities.Preference{}, nil
+			},
+			mockUserAddPreference: func(a entities.Preference) error {
+				assert.Equal(t, []int{2, 1}, a.GenderIds)
+				assert.Equal(t, 2, a.GenderId)
+				return nil
+			},
+			wantError: false,
+		},
 		{
 			name: "Fail - address already exist",
 			request: &pb.PreferenceRequest{
@@ -832,55 +845,49 @@ func TestAdminAddGender(t *testing.T) {
 	defer ctrl.Finish()
 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapters, usecase)
+	userService := service.NewUserService(adapters, usecase, nil)
 	tests := []struct {
-		name                string
-		request             *pb.AddGenderRequest
-		mockGetGenderByName func(string) (entities.Gender, error)
-		mockAdminAddGender  func(entities.Gender) error
-		wantError           bool
+		name               string
+		request            *pb.AddGenderRequest
+		mockAdminAddGender func(entities.Gender) error
+		wantCode           codes.Code
 	}{
 		{
 			name: "Success",
 			request: &pb.AddGenderRequest{
 				Gender: "ValidMale",
 			},
-			mockGetGenderByName: func(s string) (entities.Gender, error) {
-				return entities.Gender{}, nil
-			},
 			mockAdminAddGender: func(g entities.Gender) error {
 				return nil
 			},
-			wantError: false,
+			wantCode: codes.OK,
 		},
 		{
-			name: "Fail",
+			name: "Fail - gender already exist",
 			request: &pb.AddGenderRequest{
-				Gender: "ValidMale",
+				Gender: "validmale",
 			},
-			mockGetGenderByName: func(s string) (entities.Gender, error) {
-				return entities.Gender{
-					Name: "ValidMale",
-				}, nil
+			mockAdminAddGender: func(g entities.Gender) error {
+				return gorm.ErrDuplicatedKey
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "Fail - AdminAddGender error",
+			request: &pb.AddGenderRequest{
+				Gender: "ValidMale",
 			},
 			mockAdminAddGender: func(g entities.Gender) error {
-				return nil
+				return fmt.Errorf("insert failed")
 			},
-			wantError: true,
+			wantCode: codes.Unknown,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			adapters.EXPECT().GetGenderByName(gomock.Any()).DoAndReturn(test.mockGetGenderByName).AnyTimes().Times(1)
-			if !test.wantError {
-				adapters.EXPECT().AdminAddGender(gomock.Any()).DoAndReturn(test.mockAdminAddGender).AnyTimes().Times(1)
-			}
+			adapters.EXPECT().AdminAddGender(gomock.Any()).DoAndReturn(test.mockAdminAddGender).Times(1)
 			_, err := userService.AdminAddGender(context.Background(), test.request)
-			if test.wantError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
+			assert.Equal(t, test.wantCode, status.Code(err))
 		})
 	}
 }
@@ -890,7 +897,7 @@ func TestAdminAddGender(t *testing.T) {
 // 	defer ctrl.Finish()
 // 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 // 	usecase := mock_usecases.NewMockUsecases(ctrl)
-// 	userSerivce := service.NewUserService(adapters, usecase)
+// 	userSerivce := service.NewUserService(adapters, usecase, nil)
 // 	testUUID := uuid.New()
 // 	profileTestUUID := uuid.New()
 // 	temp1 := uuid.New()
@@ -961,7 +968,7 @@ func TestUserEditAddress(t *testing.T) {
 
 	adapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	usecase := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(adapters, usecase)
+	userService := service.NewUserService(adapters, usecase, nil)
 
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
@@ -1035,7 +1042,7 @@ func TestUserEditPreference(t *testing.T) {
 
 	// Mock the adapters interface
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
@@ -1105,7 +1112,7 @@ func TestGetUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 	userData := entities.User{
@@ -1172,7 +1179,7 @@ func TestUserUploadProfileImage(t *testing.T) {
 	defer ctrl.Finish()
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
 	mockUsecases := mock_usecases.NewMockUsecases(ctrl)
-	userService := service.NewUserService(mockAdapters, mockUsecases)
+	userService := service.NewUserService(mockAdapters, mockUsecases, nil)
 
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
@@ -1181,7 +1188,7 @@ func TestUserUploadProfileImage(t *testing.T) {
 		name                     string
 		request                  *pb.UserImageRequest
 		mockGetProfileIdByUserId func(string) (string, error)
-		mockUploadImage          func(*pb.UserImageRequest, string) (string, error)
+		mockUploadImage          func(context.Context, *pb.UserImageRequest, string) (string, error)
 		expectedResult           *pb.UserImageResponse
 		wantError                bool
 	}{
@@ -1194,7 +1201,7 @@ func TestUserUploadProfileImage(t *testing.T) {
 			mockGetProfileIdByUserId: func(s string) (string, error) {
 				return profileTestUUID.String(), nil
 			},
-			mockUploadImage: func(req *pb.UserImageRequest, profile string) (string, error) {
+			mockUploadImage: func(ctx context.Context, req *pb.UserImageRequest, profile string) (string, error) {
 				return "http://example.com/image.jpg", nil
 			},
 			expectedResult: &pb.UserImageResponse{
@@ -1211,7 +1218,7 @@ func TestUserUploadProfileImage(t *testing.T) {
 			mockGetProfileIdByUserId: func(s string) (string, error) {
 				return "", fmt.Errorf("profile not found")
 			},
-			mockUploadImage: func(req *pb.UserImageRequest, profile string) (string, error) {
+			mockUploadImage: func(ctx context.Context, req *pb.UserImageRequest, profile string) (string, error) {
 				return "", nil
 			},
 			expectedResult: nil,
@@ -1226,7 +1233,7 @@ func TestUserUploadProfileImage(t *testing.T) {
 			mockGetProfileIdByUserId: func(s string) (string, error) {
 				return profileTestUUID.String(), nil
 			},
-			mockUploadImage: func(req *pb.UserImageRequest, profile string) (string, error) {
+			mockUploadImage: func(ctx context.Context, req *pb.UserImageRequest, profile string) (string, error) {
 				return "", fmt.Errorf("upload failed")
 			},
 			expectedResult: nil,
@@ -1238,7 +1245,7 @@ func TestUserUploadProfileImage(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			mockAdapters.EXPECT().GetProfileIdByUserId(gomock.Any()).DoAndReturn(test.mockGetProfileIdByUserId).Times(1)
 			if !test.wantError || test.name == "Fail - UploadImage error" {
-				mockUsecases.EXPECT().UploadImage(test.request, profileTestUUID.String()).DoAndReturn(test.mockUploadImage).Times(1)
+				mockUsecases.EXPECT().UploadImage(gomock.Any(), test.request, profileTestUUID.String()).DoAndReturn(test.mockUploadImage).Times(1)
 			}
 
 			result, err := userService.UserUploadProfileImage(context.Background(), test.request)
@@ -1253,12 +1260,141 @@ func TestUserUploadProfileImage(t *testing.T) {
 	}
 }
 
+type imageStream struct {
+	pb.UserService_UploadProfileImageStreamServer
+	requests []*pb.UploadImageChunk
+	response *pb.UserImageResponse
+}
+
+func (s *imageStream) Recv() (*pb.UploadImageChunk, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *imageStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *imageStream) SendAndClose(res *pb.UserImageResponse) error {
+	s.response = res
+	return nil
+}
+
+func TestUploadProfileImageStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
+	mockUsecases := mock_usecases.NewMockUsecases(ctrl)
+	userService := service.NewUserService(mockAdapters, mockUsecases, nil)
+
+	testUUID := uuid.New()
+	profileTestUUID := uuid.New()
+	image := []byte("test_image_data")
+	sum := sha256.Sum256(image)
+	checksum := hex.EncodeToString(sum[:])
+
+	metadata := func(checksum string, size int64) *pb.UploadImageChunk {
+		return &pb.UploadImageChunk{Data: &pb.UploadImageChunk_Metadata{Metadata: &pb.UploadImageMetadata{
+			UserId:     testUUID.String(),
+			ObjectName: "image.jpg",
+			Size:       size,
+			Checksum:   checksum,
+		}}}
+	}
+	chunk := func(b []byte) *pb.UploadImageChunk {
+		return &pb.UploadImageChunk{Data: &pb.UploadImageChunk_Chunk{Chunk: b}}
+	}
+
+	tests := []struct {
+		name           string
+		requests       []*pb.UploadImageChunk
+		callsUsecase   bool
+		expectedResult *pb.UserImageResponse
+		wantError      bool
+		code           codes.Code
+	}{
+		{
+			name:           "Success",
+			requests:       []*pb.UploadImageChunk{metadata(checksum, int64(len(image))), chunk(image[:5]), chunk(image[5:])},
+			callsUsecase:   true,
+			expectedResult: &pb.UserImageResponse{Url: "http://example.com/image.jpg"},
+			wantError:      false,
+		},
+		{
+			name:         "Fail - checksum mismatch",
+			requests:     []*pb.UploadImageChunk{metadata(strings.Repeat("0", 64), 0), chunk(image)},
+			callsUsecase: true,
+			wantError:    true,
+			code:         codes.InvalidArgument,
+		},
+		{
+			name:         "Fail - size mismatch",
+			requests:     []*pb.UploadImageChunk{metadata(checksum, 4), chunk(image)},
+			callsUsecase: true,
+			wantError:    true,
+			code:         codes.InvalidArgument,
+		},
+		{
+			name:         "Fail - chunks over limit",
+			requests:     []*pb.UploadImageChunk{metadata(checksum, 0), chunk(make([]byte, 11<<20))},
+			callsUsecase: true,
+			wantError:    true,
+			code:         codes.ResourceExhausted,
+		},
+		{
+			name:      "Fail - announced size over limit",
+			requests:  []*pb.UploadImageChunk{metadata(checksum, 11<<20)},
+			wantError: true,
+			code:      codes.ResourceExhausted,
+		},
+		{
+			name:      "Fail - missing metadata",
+			requests:  []*pb.UploadImageChunk{chunk(image)},
+			wantError: true,
+			code:      codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.callsUsecase {
+				mockAdapters.EXPECT().GetProfileIdByUserId(testUUID.String()).Return(profileTestUUID.String(), nil).Times(1)
+			}
+			if test.callsUsecase {
+				mockUsecases.EXPECT().UploadImageStream(gomock.Any(), "image.jpg", gomock.Any(), gomock.Any(), profileTestUUID.String()).DoAndReturn(
+					func(ctx context.Context, objectName, contentType string, r io.Reader, profile string) (string, error) {
+						data, err := io.ReadAll(r)
+						if err != nil {
+							return "", err
+						}
+						assert.Equal(t, image, data)
+						return "http://example.com/image.jpg", nil
+					}).Times(1)
+			}
+
+			srv := &imageStream{requests: test.requests}
+			err := userService.UploadProfileImageStream(srv)
+			if test.wantError {
+				assert.Equal(t, test.code, status.Code(err))
+				assert.Nil(t, srv.response)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, srv.response)
+			}
+		})
+	}
+}
+
 func TestUserGetProfilePic(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
@@ -1341,7 +1477,7 @@ func TestUserAddAge(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
@@ -1350,11 +1486,25 @@ func TestUserAddAge(t *testing.T) {
 		name                     string
 		request                  *pb.UserAgeRequest
 		mockGetProfileIdByUserId func(string) (string, error)
-		mockUpdateAge            func(int, string) error
+		mockUpdateDateOfBirth    func(time.Time, string) error
 		expectedError            bool
 	}{
 		{
 			name: "Success",
+			request: &pb.UserAgeRequest{
+				UserId: testUUID.String(),
+				Dob:    "1990-01-01",
+			},
+			mockGetProfileIdByUserId: func(s string) (string, error) {
+				return profileTestUUID.String(), nil
+			},
+			mockUpdateDateOfBirth: func(dob time.Time, profile string) error {
+				return nil
+			},
+			expectedError: false,
+		},
+		{
+			name: "Success - RFC 3339 timestamp",
 			request: &pb.UserAgeRequest{
 				UserId: testUUID.String(),
 				Dob:    "1990-01-01T00:00:00.000000Z",
@@ -1362,7 +1512,7 @@ func TestUserAddAge(t *testing.T) {
 			mockGetProfileIdByUserId: func(s string) (string, error) {
 				return profileTestUUID.String(), nil
 			},
-			mockUpdateAge: func(age int, profile string) error {
+			mockUpdateDateOfBirth: func(dob time.Time, profile string) error {
 				return nil
 			},
 			expectedError: false,
@@ -1376,7 +1526,7 @@ func TestUserAddAge(t *testing.T) {
 			mockGetProfileIdByUserId: func(s string) (string, error) {
 				return "", fmt.Errorf("profile not found")
 			},
-			mockUpdateAge: func(age int, profile string) error {
+			mockUpdateDateOfBirth: func(dob time.Time, profile string) error {
 				return nil
 			},
 			expectedError: true,
@@ -1390,13 +1540,13 @@ func TestUserAddAge(t *testing.T) {
 			mockGetProfileIdByUserId: func(s string) (string, error) {
 				return profileTestUUID.String(), nil
 			},
-			mockUpdateAge: func(age int, profile string) error {
+			mockUpdateDateOfBirth: func(dob time.Time, profile string) error {
 				return nil
 			},
 			expectedError: true,
 		},
 		{
-			name: "Fail - UpdateAge error",
+			name: "Fail - UpdateDateOfBirth error",
 			request: &pb.UserAgeRequest{
 				UserId: testUUID.String(),
 				Dob:    "1990-01-01T00:00:00.000000Z",
@@ -1404,8 +1554,8 @@ func TestUserAddAge(t *testing.T) {
 			mockGetProfileIdByUserId: func(s string) (string, error) {
 				return profileTestUUID.String(), nil
 			},
-			mockUpdateAge: func(age int, profile string) error {
-				return fmt.Errorf("update age failed")
+			mockUpdateDateOfBirth: func(dob time.Time, profile string) error {
+				return fmt.Errorf("update date of birth failed")
 			},
 			expectedError: true,
 		},
@@ -1414,8 +1564,8 @@ func TestUserAddAge(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			mockAdapters.EXPECT().GetProfileIdByUserId(test.request.UserId).DoAndReturn(test.mockGetProfileIdByUserId).AnyTimes().Times(1)
-			if !test.expectedError || test.name == "Fail - UpdateAge error" {
-				mockAdapters.EXPECT().UpdateAge(gomock.Any(), profileTestUUID.String()).DoAndReturn(test.mockUpdateAge).AnyTimes().Times(1)
+			if !test.expectedError || test.name == "Fail - UpdateDateOfBirth error" {
+				mockAdapters.EXPECT().UpdateDateOfBirth(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), profileTestUUID.String()).DoAndReturn(test.mockUpdateDateOfBirth).AnyTimes().Times(1)
 			}
 
 			_, err := userService.UserAddAge(context.Background(), test.request)
@@ -1433,7 +1583,7 @@ func TestUserGetAge(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 	profileTestUUID := uuid.New()
@@ -1514,7 +1664,7 @@ func TestGetUserData(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 
@@ -1585,47 +1735,58 @@ func TestDecrementLikeCount(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 
 	tests := []struct {
 		name                   string
-		request                *pb.GetUserById
+		dailyLikes             int
 		mockDecrementLikeCount func(string) error
-		expectedError          bool
+		wantCode               codes.Code
 	}{
 		{
-			name: "Success",
-			request: &pb.GetUserById{
-				Id: testUUID.String(),
-			},
+			name:       "Success",
+			dailyLikes: 3,
 			mockDecrementLikeCount: func(s string) error {
 				return nil
 			},
-			expectedError: false,
+			wantCode: codes.OK,
 		},
 		{
-			name: "Fail - DecrementLikeCount error",
-			request: &pb.GetUserById{
-				Id: testUUID.String(),
+			name:       "Success - unlimited likes are not counted",
+			dailyLikes: entities.UnlimitedLikes,
+			wantCode:   codes.OK,
+		},
+		{
+			name:       "Fail - no likes left",
+			dailyLikes: 3,
+			mockDecrementLikeCount: func(s string) error {
+				return adapters.ErrNoLikesLeft
 			},
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:       "Fail - DecrementLikeCount error",
+			dailyLikes: 3,
 			mockDecrementLikeCount: func(s string) error {
 				return fmt.Errorf("decrement like count failed")
 			},
-			expectedError: true,
+			wantCode: codes.Unknown,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			mockAdapters.EXPECT().DecrementLikeCount(gomock.Any()).DoAndReturn(test.mockDecrementLikeCount).AnyTimes().Times(1)
+			mockAdapters.EXPECT().GetEntitlements(testUUID.String()).Return(helperstruct.Entitlements{PlanId: entities.PlanFree, DailyLikes: test.dailyLikes}, nil)
+			if test.mockDecrementLikeCount != nil {
+				mockAdapters.EXPECT().DecrementLikeCount(testUUID.String()).DoAndReturn(test.mockDecrementLikeCount)
+			}
 
-			_, err := userService.DecrementLikeCount(context.Background(), test.request)
-			if test.expectedError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
+			res, err := userService.DecrementLikeCount(context.Background(), &pb.GetUserById{Id: testUUID.String()})
+			assert.Equal(t, test.wantCode, status.Code(err))
+			if err == nil {
+				assert.NotNil(t, res)
 			}
 		})
 	}
@@ -1636,15 +1797,15 @@ func TestUpdateSubscription(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAdapters := mock_adapters.NewMockAdapterInterface(ctrl)
-	userService := service.NewUserService(mockAdapters, nil)
+	userService := service.NewUserService(mockAdapters, nil, nil)
 
 	testUUID := uuid.New()
 
 	tests := []struct {
-		name                   string
-		request                *pb.UpdateSubscriptionRequest
-		mockUpdateSubscription func(string, bool) error
-		expectedError          bool
+		name                       string
+		request                    *pb.UpdateSubscriptionRequest
+		mockApplySubscriptionEvent func(entities.SubscriptionEvent, *entities.Subscription) (string, error)
+		expectedError              bool
 	}{
 		{
 			name: "Success",
@@ -1652,19 +1813,25 @@ func TestUpdateSubscription(t *testing.T) {
 				UserId:       testUUID.String(),
 				Subscription: true,
 			},
-			mockUpdateSubscription: func(userId string, subscription bool) error {
-				return nil
+			mockApplySubscriptionEvent: func(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error) {
+				assert.Equal(t, entities.SubscriptionEventActivated, event.Type)
+				if assert.NotNil(t, sub) {
+					assert.Equal(t, entities.PlanPlus, sub.PlanId)
+					assert.Equal(t, entities.SubscriptionActive, sub.Status)
+					assert.Equal(t, sub.StartedAt.Add(service.DefaultSubscriptionPeriod), sub.ExpiresAt)
+				}
+				return entities.SubscriptionEventApplied, nil
 			},
 			expectedError: false,
 		},
 		{
-			name: "Fail - UpdateSubscription error",
+			name: "Fail - ApplySubscriptionEvent error",
 			request: &pb.UpdateSubscriptionRequest{
 				UserId:       testUUID.String(),
 				Subscription: true,
 			},
-			mockUpdateSubscription: func(userId string, subscription bool) error {
-				return fmt.Errorf("update subscription failed")
+			mockApplySubscriptionEvent: func(event entities.SubscriptionEvent, sub *entities.Subscription) (string, error) {
+				return "", fmt.Errorf("update subscription failed")
 			},
 			expectedError: true,
 		},
@@ -1672,7 +1839,7 @@ func TestUpdateSubscription(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			mockAdapters.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(test.mockUpdateSubscription).AnyTimes().Times(1)
+			mockAdapters.EXPECT().ApplySubscriptionEvent(gomock.Any(), gomock.Any()).DoAndReturn(test.mockApplySubscriptionEvent).Times(1)
 
 			_, err := userService.UpdateSubscription(context.Background(), test.request)
 			if test.expectedError {
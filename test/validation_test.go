@@ -0,0 +1,270 @@
+package userServiceTest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akshaybt001/DatingApp_UserService/internal/helper"
+	"github.com/akshaybt001/DatingApp_UserService/internal/validation"
+	"github.com/akshaybt001/DatingApp_proto_files/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const validUserId = "0b6f2f52-3c1e-4a8e-9d43-5a2c1f0e7b11"
+
+func dobYearsAgo(years int) string {
+	return time.Now().AddDate(-years, 0, -1).UTC().Format(helper.DOBLayout)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    interface{}
+		fields []string
+	}{
+		{
+			name: "valid signup",
+			req:  &pb.UserSignupRequest{Name: "valid", Email: "valid@gmail.com", Phone: "+918888888888", Password: "valid"},
+		},
+		{
+			name:   "signup with bad email and phone",
+			req:    &pb.UserSignupRequest{Name: "valid", Email: "not-an-email", Phone: "8888888888", Password: "valid"},
+			fields: []string{"email", "phone"},
+		},
+		{
+			name:   "signup with empty name and long password",
+			req:    &pb.UserSignupRequest{Email: "valid@gmail.com", Phone: "+918888888888", Password: string(make([]byte, 73))},
+			fields: []string{"name", "password"},
+		},
+		{
+			name:   "user id is not a uuid",
+			req:    &pb.GetUserById{Id: "1"},
+			fields: []string{"id"},
+		},
+		{
+			name: "valid preference",
+			req:  &pb.PreferenceRequest{UserId: validUserId, Minage: 18, Maxage: 30, Gender: 1, Desirecity: "kochi"},
+		},
+		{
+			name:   "preference with min age above max age",
+			req:    &pb.PreferenceRequest{UserId: validUserId, Minage: 40, Maxage: 30, Gender: 1, Desirecity: "kochi"},
+			fields: []string{"minage"},
+		},
+		{
+			name:   "preference with an invalid gender in the set",
+			req:    &pb.PreferenceRequest{UserId: validUserId, Minage: 18, Maxage: 30, Genders: []int32{1, 0}, Desirecity: "kochi"},
+			fields: []string{"genders[1]"},
+		},
+		{
+			name:   "preference with negative ages",
+			req:    &pb.PreferenceResponse{UserId: validUserId, Minage: -1, Maxage: -1, Gender: 1, Desirecity: "kochi"},
+			fields: []string{"minage", "maxage"},
+		},
+		{
+			name: "adult date of birth",
+			req:  &pb.UserAgeRequest{UserId: validUserId, Dob: dobYearsAgo(25)},
+		},
+		{
+			name:   "under 18",
+			req:    &pb.UserAgeRequest{UserId: validUserId, Dob: dobYearsAgo(17)},
+			fields: []string{"dob"},
+		},
+		{
+			name:   "date of birth in the future",
+			req:    &pb.UserAgeRequest{UserId: validUserId, Dob: time.Now().AddDate(1, 0, 0).UTC().Format(helper.DOBLayout)},
+			fields: []string{"dob"},
+		},
+		{
+			name:   "address field too long",
+			req:    &pb.AddAddressRequest{UserId: validUserId, Country: "india", State: "kerala", District: "ernakulam", City: string(make([]rune, 101))},
+			fields: []string{"city"},
+		},
+		{
+			name:   "interest name too long",
+			req:    &pb.AddInterestRequest{Interest: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+			fields: []string{"interest"},
+		},
+		{
+			name: "profile update ignores fields outside the mask",
+			req: &pb.UpdateProfileRequest{
+				UserId:     validUserId,
+				Profile:    &pb.ProfileDetails{Bio: "hello", HeightCm: 20},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"bio"}},
+			},
+		},
+		{
+			name: "profile update with invalid fields",
+			req: &pb.UpdateProfileRequest{
+				UserId: validUserId,
+				Profile: &pb.ProfileDetails{
+					HeightCm: 20,
+					Drinking: pb.Frequency(9),
+					Prompts:  []*pb.ProfilePrompt{{Question: "Q", Answer: "A"}, {Question: "q ", Answer: ""}},
+				},
+			},
+			fields: []string{"profile.name", "profile.height_cm", "profile.drinking", "profile.prompts[1].question", "profile.prompts[1].answer"},
+		},
+		{
+			name: "profile update mask with an unknown field",
+			req: &pb.UpdateProfileRequest{
+				UserId:     validUserId,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+			},
+			fields: []string{"update_mask"},
+		},
+		{
+			name: "account update checks only masked fields",
+			req: &pb.UpdateAccountRequest{
+				UserId:     validUserId,
+				Account:    &pb.AccountDetails{Email: "new@example.com", Phone: "12345"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+			},
+		},
+		{
+			name: "account update with invalid fields",
+			req: &pb.UpdateAccountRequest{
+				UserId:  validUserId,
+				Account: &pb.AccountDetails{Email: "not an email", Phone: "12345"},
+			},
+			fields: []string{"account.name", "account.email", "account.phone"},
+		},
+		{
+			name:   "confirming an unknown field",
+			req:    &pb.ConfirmAccountChangeRequest{UserId: validUserId, Field: "name", Value: "valid", Code: "123456"},
+			fields: []string{"field"},
+		},
+		{
+			name:   "confirming an invalid phone",
+			req:    &pb.ConfirmAccountChangeRequest{UserId: validUserId, Field: "phone", Value: "98765", Code: "123456"},
+			fields: []string{"value"},
+		},
+		{
+			name:   "confirming without a code",
+			req:    &pb.ConfirmAccountChangeRequest{UserId: validUserId, Field: "phone", Value: "+919876543299", Code: "12a456"},
+			fields: []string{"code"},
+		},
+		{
+			name:   "subscription with a bad expiry",
+			req:    &pb.UpdateSubscriptionRequest{UserId: validUserId, Subscription: true, PlanId: "gold", ExpiresAt: "next month"},
+			fields: []string{"expires_at"},
+		},
+		{
+			name:   "subscription that already ended",
+			req:    &pb.UpdateSubscriptionRequest{UserId: validUserId, Subscription: true, ExpiresAt: "2020-01-01T00:00:00Z"},
+			fields: []string{"expires_at"},
+		},
+		{
+			name: "valid subscription refund",
+			req:  &pb.SubscriptionEventRequest{EventId: "evt_1", UserId: validUserId, Type: pb.SubscriptionEventType_REFUNDED, OccurredAt: "2026-05-01T10:00:00Z"},
+		},
+		{
+			name:   "subscription activation without plan or expiry",
+			req:    &pb.SubscriptionEventRequest{EventId: "evt_1", UserId: validUserId, Type: pb.SubscriptionEventType_ACTIVATED, OccurredAt: "2026-05-01T10:00:00Z"},
+			fields: []string{"plan_id", "expires_at"},
+		},
+		{
+			name:   "subscription event without id, time or type",
+			req:    &pb.SubscriptionEventRequest{UserId: validUserId},
+			fields: []string{"event_id", "occurred_at", "type"},
+		},
+		{
+			name:   "unknown discovery mode",
+			req:    &pb.DiscoveryModeRequest{UserId: validUserId, Mode: pb.DiscoveryMode(7)},
+			fields: []string{"mode"},
+		},
+		{
+			name:   "liking yourself",
+			req:    &pb.UserLikeRequest{UserId: validUserId, LikedId: validUserId},
+			fields: []string{"liked_id"},
+		},
+		{
+			name:   "imported like with a malformed time",
+			req:    &pb.UserLikeRequest{UserId: validUserId, LikedId: "5d0c8e1a-7f2b-4c3d-8e9f-1a2b3c4d5e6f", LikedAt: "yesterday"},
+			fields: []string{"liked_at"},
+		},
+		{
+			name: "translation with a regional locale",
+			req:  &pb.TranslationRequest{Entity: "interest", EntityId: 1, Locale: "pt_BR", Name: "música"},
+		},
+		{
+			name:   "translation of an unknown entity and locale",
+			req:    &pb.TranslationKey{Entity: "city", EntityId: 1, Locale: "portuguese!"},
+			fields: []string{"entity", "locale"},
+		},
+		{
+			name:   "setting an invalid interest",
+			req:    &pb.SetUserInterestsRequest{UserId: validUserId, InterestIds: []int32{1, 0}},
+			fields: []string{"interest_ids[1]"},
+		},
+		{
+			name:   "category with a negative position",
+			req:    &pb.InterestCategoryRequest{Name: "Sports", Position: -1},
+			fields: []string{"position"},
+		},
+		{
+			name:   "merging an entry into itself",
+			req:    &pb.MergeCatalogRequest{SourceId: 3, TargetId: 3},
+			fields: []string{"target_id"},
+		},
+		{
+			name:   "deleting a catalog entry without an id",
+			req:    &pb.DeleteCatalogRequest{},
+			fields: []string{"id"},
+		},
+		{
+			name:   "stream metadata with bad checksum",
+			req:    &pb.UploadImageChunk{Data: &pb.UploadImageChunk_Metadata{Metadata: &pb.UploadImageMetadata{UserId: validUserId, ObjectName: "pic", Checksum: "abc"}}},
+			fields: []string{"metadata.checksum"},
+		},
+		{
+			name: "stream chunk",
+			req:  &pb.UploadImageChunk{Data: &pb.UploadImageChunk_Chunk{Chunk: []byte("data")}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validation.Validate(test.req)
+			if len(test.fields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			var fields []string
+			for _, detail := range st.Details() {
+				if br, ok := detail.(*errdetails.BadRequest); ok {
+					for _, v := range br.FieldViolations {
+						fields = append(fields, v.Field)
+					}
+				}
+			}
+			for _, field := range test.fields {
+				assert.Contains(t, fields, field)
+			}
+		})
+	}
+}
+
+func TestValidationInterceptor(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return &pb.NoArg{}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/UserAddPreference"}
+
+	_, err := validation.UnaryServerInterceptor(context.Background(), &pb.PreferenceRequest{UserId: validUserId, Minage: 40, Maxage: 30, Gender: 1, Desirecity: "kochi"}, info, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, called)
+
+	_, err = validation.UnaryServerInterceptor(context.Background(), &pb.PreferenceRequest{UserId: validUserId, Minage: 18, Maxage: 30, Gender: 1, Desirecity: "kochi"}, info, handler)
+	assert.NoError(t, err)
+	assert.True(t, called)
+}